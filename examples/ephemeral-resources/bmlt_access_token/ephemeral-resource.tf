@@ -0,0 +1,19 @@
+# Mint a short-lived access token that never lands in state
+ephemeral "bmlt_access_token" "automation" {
+  username = "automation"
+  password = var.automation_password
+}
+
+# Use the token to configure an aliased provider
+provider "bmlt" {
+  alias        = "automation"
+  host         = "https://example.com/main_server"
+  access_token = ephemeral.bmlt_access_token.automation.access_token
+}
+
+variable "automation_password" {
+  description = "Password for the automation user"
+  type        = string
+  sensitive   = true
+  ephemeral   = true
+}
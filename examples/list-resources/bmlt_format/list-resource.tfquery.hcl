@@ -0,0 +1,8 @@
+list "bmlt_format" "all" {
+  provider         = bmlt
+  include_resource = true
+
+  config {
+    language = "en"
+  }
+}
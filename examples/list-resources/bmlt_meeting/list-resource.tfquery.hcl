@@ -0,0 +1,10 @@
+# Find the meetings of one service body and generate their configuration with
+#   terraform query -generate-config-out=meetings.tf
+list "bmlt_meeting" "area" {
+  provider         = bmlt
+  include_resource = true
+
+  config {
+    service_body_ids = "42"
+  }
+}
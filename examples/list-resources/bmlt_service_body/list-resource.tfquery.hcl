@@ -0,0 +1,8 @@
+list "bmlt_service_body" "regions" {
+  provider         = bmlt
+  include_resource = true
+
+  config {
+    parent_id = 1
+  }
+}
@@ -0,0 +1,4 @@
+list "bmlt_user" "all" {
+  provider         = bmlt
+  include_resource = true
+}
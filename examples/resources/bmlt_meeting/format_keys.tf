@@ -0,0 +1,16 @@
+# Format keys are resolved to the server's format identifiers, so the same
+# configuration can be applied to root servers with different format IDs
+resource "bmlt_meeting" "online" {
+  service_body_id      = 1
+  format_keys          = ["O", "BT", "VM"]
+  venue_type           = 2 # Virtual
+  day                  = 3 # Wednesday
+  start_time           = "12:00"
+  duration             = "01:00"
+  time_zone            = "America/New_York"
+  latitude             = 40.7128
+  longitude            = -74.0060
+  published            = true
+  name                 = "Wednesday Lunch Online"
+  virtual_meeting_link = "https://example.com/meeting"
+}
@@ -0,0 +1,5 @@
+# The meeting list can also be maintained in a spreadsheet and exported as CSV
+resource "bmlt_meeting_schedule" "from_spreadsheet" {
+  service_body_id = 42
+  meetings_csv    = file("${path.module}/meetings.csv")
+}
@@ -0,0 +1,3 @@
+# Schedules can be imported using the service body ID. All meetings of the
+# service body are imported, keyed by their meeting ID.
+terraform import bmlt_meeting_schedule.lake_area 42
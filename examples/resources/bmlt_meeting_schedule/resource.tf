@@ -0,0 +1,33 @@
+# Meetings are keyed by a stable external key, so renaming or moving a
+# meeting updates it in place instead of replacing it
+resource "bmlt_meeting_schedule" "lake_area" {
+  service_body_id = 42
+
+  meetings = {
+    "hope-mon" = {
+      name            = "Monday Night Hope"
+      day             = 1 # Monday
+      start_time      = "19:00"
+      duration        = "01:30"
+      time_zone       = "America/New_York"
+      venue_type      = 1 # In-person
+      latitude        = 40.7128
+      longitude       = -74.0060
+      format_keys     = ["O", "BT"]
+      location_text   = "Community Center"
+      location_street = "123 Main St"
+    }
+    "lunch-wed" = {
+      name                 = "Wednesday Lunch Online"
+      day                  = 3 # Wednesday
+      start_time           = "12:00"
+      duration             = "01:00"
+      time_zone            = "America/New_York"
+      venue_type           = 2 # Virtual
+      latitude             = 40.7128
+      longitude            = -74.0060
+      format_keys          = ["O", "VM"]
+      virtual_meeting_link = "https://example.com/meeting"
+    }
+  }
+}
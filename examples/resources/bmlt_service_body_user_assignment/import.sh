@@ -0,0 +1,2 @@
+# Assignments can be imported using the service body ID and user ID
+terraform import bmlt_service_body_user_assignment.editor 42/7
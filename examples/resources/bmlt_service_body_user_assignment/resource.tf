@@ -0,0 +1,13 @@
+# Manage the service body without managing its user assignments
+resource "bmlt_service_body" "area" {
+  name          = "Example Area"
+  description   = "Example Area Service Committee"
+  type          = "AS"
+  admin_user_id = 1
+}
+
+# Assign an editor to the service body from another configuration
+resource "bmlt_service_body_user_assignment" "editor" {
+  service_body_id = bmlt_service_body.area.id
+  user_id         = 7
+}
@@ -0,0 +1,19 @@
+# Let the provider generate the password and rotate it every 90 days
+resource "bmlt_user" "treasurer" {
+  username          = "treasurer"
+  generate_password = true
+  password_length   = 32
+  rotate_after      = "2160h"
+  type              = "serviceBodyAdmin"
+  display_name      = "Area Treasurer"
+
+  # Changing any keeper generates a new password
+  keepers = {
+    trusted_servant = "Jane D."
+  }
+}
+
+output "treasurer_password" {
+  value     = bmlt_user.treasurer.generated_password
+  sensitive = true
+}
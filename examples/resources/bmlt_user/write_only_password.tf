@@ -0,0 +1,15 @@
+# Keep the password out of state with a write-only attribute
+resource "bmlt_user" "secretary" {
+  username            = "secretary"
+  password_wo         = var.secretary_password
+  password_wo_version = 1 # Increment to rotate the password
+  type                = "serviceBodyAdmin"
+  display_name        = "Area Secretary"
+}
+
+variable "secretary_password" {
+  description = "Password for the secretary user"
+  type        = string
+  sensitive   = true
+  ephemeral   = true
+}
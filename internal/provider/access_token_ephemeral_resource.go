@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
+)
+
+var _ ephemeral.EphemeralResource = &AccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &AccessTokenEphemeralResource{}
+
+// accessTokenPrivateKey is the private data key holding the token to revoke on close
+const accessTokenPrivateKey = "access_token"
+
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AccessTokenEphemeralResource{}
+}
+
+type AccessTokenEphemeralResource struct {
+	client *BMTLClientData
+}
+
+type AccessTokenEphemeralResourceModel struct {
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	Revoke      types.Bool   `tfsdk:"revoke"`
+	AccessToken types.String `tfsdk:"access_token"`
+	TokenType   types.String `tfsdk:"token_type"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	UserId      types.Int64  `tfsdk:"user_id"`
+}
+
+func (r *AccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *AccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Obtains a short-lived BMLT access token using the username/password grant. The token is never stored in the plan or state, " +
+			"so it can be passed to other providers or to an aliased bmlt provider.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username to authenticate as",
+				Required:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the user",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"revoke": schema.BoolAttribute{
+				MarkdownDescription: "Whether to revoke the token once Terraform no longer needs it. Defaults to true.",
+				Optional:            true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "OAuth2 access token",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token_type": schema.StringAttribute{
+				MarkdownDescription: "Token type, e.g. bearer",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "Expiry time of the token in RFC 3339 format, or null if the server did not report one",
+				Computed:            true,
+			},
+			"user_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the authenticated user",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *AccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BMTLClientData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			clientTypeError(req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AccessTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Provider data is missing while the provider configuration is still unknown
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider must be configured before an access token can be requested. "+
+				"Make sure the provider host does not depend on values that are only known after apply.",
+		)
+		return
+	}
+
+	// Token requests use the provider HTTP client so they honor the TLS, proxy and retry settings
+	oauthCtx := context.WithValue(ctx, oauth2.HTTPClient, r.client.HTTPClient)
+	tokenSource := newPasswordTokenSource(oauthCtx, r.client.Client, r.client.BaseURL.String(), data.Username.ValueString(), data.Password.ValueString())
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Obtain BMLT Access Token",
+			"An unexpected error occurred when requesting an access token: "+err.Error(),
+		)
+		return
+	}
+
+	data.AccessToken = types.StringValue(token.AccessToken)
+	data.TokenType = types.StringValue(token.TokenType)
+	data.ExpiresAt = types.StringNull()
+	if !token.Expiry.IsZero() {
+		data.ExpiresAt = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+	}
+	data.UserId = types.Int64Null()
+	if userId, ok := token.Extra("user_id").(float64); ok {
+		data.UserId = types.Int64Value(int64(userId))
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	if data.Revoke.IsNull() || data.Revoke.ValueBool() {
+		privateToken, err := json.Marshal(token.AccessToken)
+		if err != nil {
+			resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to store the access token for revocation: %s", err))
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, accessTokenPrivateKey, privateToken)...)
+	}
+}
+
+func (r *AccessTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateToken, diags := req.Private.GetKey(ctx, accessTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || privateToken == nil {
+		return
+	}
+
+	var accessToken string
+	if err := json.Unmarshal(privateToken, &accessToken); err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to read the access token to revoke: %s", err))
+		return
+	}
+
+	token := &oauth2.Token{AccessToken: accessToken, TokenType: "bearer"}
+	authCtx := context.WithValue(ctx, bmlt.ContextOAuth2, oauth2.StaticTokenSource(token))
+
+	// A token that already expired or was revoked needs no further cleanup
+	httpResp, err := r.client.Client.RootServerAPI.AuthLogout(authCtx).Execute()
+	if httpResp != nil && httpResp.StatusCode == http.StatusUnauthorized {
+		tflog.Debug(ctx, "BMLT access token was already invalid")
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "revoke access token", httpResp, err, nil)
+	}
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
+)
+
+// tokenRefreshMargin is how long before expiry a token is proactively renewed
+const tokenRefreshMargin = time.Minute
+
+// Ensure bmltTokenSource satisfies the oauth2.TokenSource interface.
+var _ oauth2.TokenSource = &bmltTokenSource{}
+
+// bmltTokenSource is the oauth2.TokenSource shared by all resources and data
+// sources through BMTLClientData. Tokens close to expiry are renewed with the
+// BMLT refresh endpoint, falling back to the password grant when the provider
+// was configured with a username and password. Tokens rejected by the server
+// are renewed by reauthTransport.
+type bmltTokenSource struct {
+	mu    sync.Mutex
+	token *oauth2.Token
+
+	// ctx is used for token requests and carries the provider HTTP client
+	ctx    context.Context
+	client *bmlt.APIClient
+
+	// oauthConfig, username and password are only set for password grant authentication
+	oauthConfig *oauth2.Config
+	username    string
+	password    string
+}
+
+// newAccessTokenSource returns a token source for a preconfigured access token
+func newAccessTokenSource(ctx context.Context, client *bmlt.APIClient, accessToken string) *bmltTokenSource {
+	return &bmltTokenSource{
+		token: &oauth2.Token{
+			AccessToken: accessToken,
+			TokenType:   "bearer",
+		},
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+// newPasswordTokenSource returns a token source using the OAuth2 password grant
+// against the BMLT token endpoint. No token is requested until one is needed.
+func newPasswordTokenSource(ctx context.Context, client *bmlt.APIClient, baseURL string, username, password string) *bmltTokenSource {
+	return &bmltTokenSource{
+		ctx:    ctx,
+		client: client,
+		oauthConfig: &oauth2.Config{
+			Endpoint: oauth2.Endpoint{
+				TokenURL: baseURL + apiPathSuffix + "/auth/token",
+			},
+		},
+		username: username,
+		password: password,
+	}
+}
+
+// Token returns the current token, renewing it first if it is missing or about to expire
+func (s *bmltTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && !tokenExpiresSoon(s.token) {
+		return s.token, nil
+	}
+
+	return s.renewLocked(true)
+}
+
+// renew replaces a token the server rejected. If another request already
+// renewed the token, the current token is returned instead.
+func (s *bmltTokenSource) renew(rejected string) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.AccessToken != rejected {
+		return s.token, nil
+	}
+
+	// The refresh endpoint requires a valid token, so a rejected token can
+	// only be replaced by authenticating again
+	return s.renewLocked(false)
+}
+
+// renewLocked obtains a new token. The caller must hold s.mu.
+func (s *bmltTokenSource) renewLocked(allowRefresh bool) (*oauth2.Token, error) {
+	var refreshErr error
+	if allowRefresh && s.token != nil {
+		token, err := s.refresh()
+		if err == nil {
+			s.token = token
+			return token, nil
+		}
+		refreshErr = err
+		tflog.Debug(s.ctx, "Unable to refresh BMLT access token", map[string]interface{}{"error": err.Error()})
+	}
+
+	if s.oauthConfig == nil {
+		if refreshErr != nil {
+			return nil, fmt.Errorf("the access token could not be refreshed: %w", refreshErr)
+		}
+		return nil, errors.New("the access token was rejected by the server and cannot be renewed without a username and password")
+	}
+
+	token, err := s.oauthConfig.PasswordCredentialsToken(s.ctx, s.username, s.password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to authenticate with username/password: %w", err)
+	}
+	token.Expiry = tokenExpiry(token.Extra("expires_at"))
+
+	tflog.Debug(s.ctx, "Obtained BMLT access token using username/password")
+	s.token = token
+	return token, nil
+}
+
+// refresh exchanges the current token for a new one using the BMLT refresh endpoint
+func (s *bmltTokenSource) refresh() (*oauth2.Token, error) {
+	ctx := context.WithValue(s.ctx, bmlt.ContextOAuth2, oauth2.StaticTokenSource(s.token))
+
+	refreshed, httpResp, err := s.client.RootServerAPI.AuthRefresh(ctx).Execute()
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != HTTPStatusOK {
+		return nil, fmt.Errorf("the refresh endpoint returned %s", httpResp.Status)
+	}
+
+	tflog.Debug(s.ctx, "Refreshed BMLT access token")
+	return &oauth2.Token{
+		AccessToken: refreshed.AccessToken,
+		TokenType:   refreshed.TokenType,
+		Expiry:      tokenExpiry(refreshed.ExpiresAt),
+	}, nil
+}
+
+// tokenExpiresSoon reports whether a token expires within tokenRefreshMargin.
+// Tokens without a known expiry never expire.
+func tokenExpiresSoon(token *oauth2.Token) bool {
+	return !token.Expiry.IsZero() && time.Until(token.Expiry) < tokenRefreshMargin
+}
+
+// tokenExpiry converts the expires_at unix timestamp returned by the BMLT
+// token endpoints to a time, returning the zero time when it is not set
+func tokenExpiry(expiresAt interface{}) time.Time {
+	var seconds int64
+	switch v := expiresAt.(type) {
+	case float64:
+		seconds = int64(v)
+	case int32:
+		seconds = int64(v)
+	case int64:
+		seconds = v
+	}
+	if seconds <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+// reauthTransport is an http.RoundTripper that renews the access token when
+// the server responds with 401 and retries the failed request once
+type reauthTransport struct {
+	next   http.RoundTripper
+	tokens *bmltTokenSource
+}
+
+func (t *reauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || t.tokens == nil || isAuthRequest(req) {
+		return resp, err
+	}
+
+	rejected, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return resp, nil
+	}
+
+	token, renewErr := t.tokens.renew(rejected)
+	if renewErr != nil {
+		tflog.Warn(req.Context(), "Unable to renew rejected BMLT access token", map[string]interface{}{"error": renewErr.Error()})
+		return resp, nil
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	token.SetAuthHeader(retry)
+
+	tflog.Debug(req.Context(), "Retrying BMLT API request with renewed access token", map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    req.URL.Redacted(),
+	})
+	return t.next.RoundTrip(retry)
+}
+
+// isAuthRequest reports whether a request targets the BMLT authentication
+// endpoints, which must never trigger re-authentication themselves
+func isAuthRequest(req *http.Request) bool {
+	return strings.Contains(req.URL.Path, apiPathSuffix+"/auth/")
+}
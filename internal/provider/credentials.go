@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// credentialHelperTimeout bounds how long a credential helper may run
+const credentialHelperTimeout = 30 * time.Second
+
+// credentialHelperOutput is the JSON object a credential helper writes to
+// stdout. It must contain either username and password, or access_token.
+type credentialHelperOutput struct {
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	AccessToken string `json:"access_token"`
+}
+
+// readSecretFile reads a password or access token from a file, ignoring
+// leading and trailing whitespace such as a final newline
+func readSecretFile(name string) (string, error) {
+	contents, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	secret := strings.TrimSpace(string(contents))
+	if secret == "" {
+		return "", fmt.Errorf("%s is empty", name)
+	}
+	return secret, nil
+}
+
+// runCredentialHelper runs a credential helper command through the system
+// shell and decodes the credentials it writes to stdout. The helper receives
+// the configured host in the BMLT_HOST environment variable.
+func runCredentialHelper(ctx context.Context, command, host string) (*credentialHelperOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialHelperTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(), "BMLT_HOST="+host)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	var output credentialHelperOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("the output is not a JSON object with username/password or access_token: %w", err)
+	}
+
+	if output.AccessToken == "" && (output.Username == "" || output.Password == "") {
+		return nil, fmt.Errorf("the output must contain either username and password, or access_token")
+	}
+	return &output, nil
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// apiErrorPayload is the JSON error body returned by the BMLT server.
+// Validation failures (422) populate Errors with messages keyed by field name.
+type apiErrorPayload struct {
+	Message string              `json:"message"`
+	Errors  map[string][]string `json:"errors"`
+}
+
+// addAPIError appends diagnostics describing a failed BMLT API call.
+//
+// action describes the attempted operation (e.g., "create meeting"). When model
+// is a resource model, validation errors for fields that correspond to one of
+// its attributes are reported against that attribute; all other errors are
+// reported without an attribute path.
+func addAPIError(diags *diag.Diagnostics, action string, httpResp *http.Response, err error, model interface{}) {
+	addAPIErrorAt(diags, path.Empty(), action, httpResp, err, model)
+}
+
+// addAPIErrorAt is addAPIError for a model nested at base, such as one element
+// of a collection attribute. All errors are reported against base or below it.
+func addAPIErrorAt(diags *diag.Diagnostics, base path.Path, action string, httpResp *http.Response, err error, model interface{}) {
+	addError := func(p path.Path, summary, detail string) {
+		if p.Equal(path.Empty()) {
+			diags.AddError(summary, detail)
+			return
+		}
+		diags.AddAttributeError(p, summary, detail)
+	}
+
+	// Transport errors and failures to decode a successful response
+	if httpResp == nil || (err != nil && httpResp.StatusCode < http.StatusMultipleChoices) {
+		addError(base, "Client Error", fmt.Sprintf("Unable to %s, got error: %s", action, err))
+		return
+	}
+
+	if httpResp.StatusCode < http.StatusMultipleChoices {
+		addError(base, "API Error", fmt.Sprintf("Unable to %s, the server returned an unexpected status: %s", action, httpResp.Status))
+		return
+	}
+
+	summary := apiErrorSummary(httpResp.StatusCode)
+	body := apiErrorBody(httpResp, err)
+
+	var payload apiErrorPayload
+	if jsonErr := json.Unmarshal(body, &payload); jsonErr != nil {
+		payload.Message = strings.TrimSpace(string(body))
+	}
+
+	if len(payload.Errors) == 0 {
+		detail := fmt.Sprintf("Unable to %s, the server returned %s", action, httpResp.Status)
+		if payload.Message != "" {
+			detail += ": " + payload.Message
+		}
+		if hint := apiErrorHint(httpResp.StatusCode); hint != "" {
+			detail += "\n\n" + hint
+		}
+		addError(base, summary, detail)
+		return
+	}
+
+	// Report field errors in a stable order
+	fields := make([]string, 0, len(payload.Errors))
+	for field := range payload.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	attributes := modelAttributeNames(model)
+	for _, field := range fields {
+		attribute := apiFieldAttributeName(field)
+		for _, message := range payload.Errors[field] {
+			if attributes[attribute] {
+				addError(base.AtName(attribute), summary, fmt.Sprintf("Unable to %s: %s", action, message))
+				continue
+			}
+			addError(base, summary, fmt.Sprintf("Unable to %s, field %q: %s", action, field, message))
+		}
+	}
+}
+
+// apiErrorSummary returns the diagnostic summary for an HTTP error status code
+func apiErrorSummary(statusCode int) string {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return "Authentication Failed"
+	case statusCode == http.StatusForbidden:
+		return "Permission Denied"
+	case statusCode == http.StatusNotFound:
+		return "Not Found"
+	case statusCode == http.StatusConflict:
+		return "Conflict"
+	case statusCode == http.StatusUnprocessableEntity:
+		return "Validation Failed"
+	case statusCode >= http.StatusInternalServerError:
+		return "Server Error"
+	default:
+		return "API Error"
+	}
+}
+
+// apiErrorHint returns additional guidance for an HTTP error status code
+func apiErrorHint(statusCode int) string {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return "Check the provider credentials. The access token may have expired or the username/password may be incorrect."
+	case statusCode == http.StatusForbidden:
+		return "The authenticated user does not have permission to perform this operation. " +
+			"Check the user type and the service bodies assigned to the user."
+	case statusCode == http.StatusNotFound:
+		return "The object may have been deleted outside of Terraform."
+	case statusCode == http.StatusConflict:
+		return "The object is still referenced by other objects on the server."
+	case statusCode >= http.StatusInternalServerError:
+		return "The BMLT server encountered an internal error. Check the server logs for details."
+	default:
+		return ""
+	}
+}
+
+// apiErrorBody returns the raw response body of a failed API call
+func apiErrorBody(httpResp *http.Response, err error) []byte {
+	var apiErr *bmlt.GenericOpenAPIError
+	if errors.As(err, &apiErr) && len(apiErr.Body()) > 0 {
+		return apiErr.Body()
+	}
+
+	// The generated client restores the response body after reading it
+	if httpResp.Body == nil {
+		return nil
+	}
+	body, readErr := io.ReadAll(httpResp.Body)
+	if readErr != nil {
+		return nil
+	}
+	return body
+}
+
+// apiFieldAttributeName converts a BMLT API field name such as "serviceBodyId"
+// or "formatIds.0" to the matching root Terraform attribute name
+func apiFieldAttributeName(field string) string {
+	root, _, _ := strings.Cut(field, ".")
+
+	var b strings.Builder
+	for i, r := range root {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// modelAttributeNames returns the set of attribute names declared by the tfsdk
+// struct tags of a model
+func modelAttributeNames(model interface{}) map[string]bool {
+	names := make(map[string]bool)
+	if model == nil {
+		return names
+	}
+
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("tfsdk"); tag != "" && tag != "-" {
+			names[tag] = true
+		}
+	}
+	return names
+}
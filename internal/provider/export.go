@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ExportOptions configures Export. Settings left empty fall back to the
+// environment variables the provider reads, which is also where the password,
+// access token or credential helper are taken from.
+type ExportOptions struct {
+	Host     string
+	Username string
+	OutDir   string
+	Version  string
+}
+
+// exportFile is a generated configuration file
+type exportFile struct {
+	name     string
+	contents strings.Builder
+}
+
+// exporter renders the objects of a root server as Terraform configuration
+type exporter struct {
+	client *BMTLClientData
+
+	// Resource names of the exported objects by ID, used for references
+	userNames        map[int32]string
+	formatNames      map[int32]string
+	serviceBodyNames map[int32]string
+
+	imports strings.Builder
+}
+
+// Export reads the settings, formats, service bodies, users and meetings of a
+// root server and writes Terraform configuration managing them to
+// opts.OutDir, together with import blocks adopting the existing objects.
+// Existing files are never overwritten.
+func Export(ctx context.Context, opts ExportOptions) error {
+	client, err := configureExportClient(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	e := &exporter{
+		client:           client,
+		userNames:        make(map[int32]string),
+		formatNames:      make(map[int32]string),
+		serviceBodyNames: make(map[int32]string),
+	}
+
+	files := []*exportFile{
+		{name: "provider.tf"},
+		{name: "settings.tf"},
+		{name: "users.tf"},
+		{name: "formats.tf"},
+		{name: "service_bodies.tf"},
+		{name: "meetings.tf"},
+		{name: "imports.tf"},
+	}
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(opts.OutDir, file.name)); err == nil {
+			return fmt.Errorf("%s already exists in %s, export to an empty directory", file.name, opts.OutDir)
+		}
+	}
+
+	// Users, formats and service bodies are exported before the objects referencing them
+	exports := []func(context.Context, *strings.Builder) error{
+		e.exportProvider,
+		e.exportSettings,
+		e.exportUsers,
+		e.exportFormats,
+		e.exportServiceBodies,
+		e.exportMeetings,
+	}
+	for i, export := range exports {
+		if err := export(ctx, &files[i].contents); err != nil {
+			return err
+		}
+	}
+	files[len(files)-1].contents.WriteString(e.imports.String())
+
+	if err := os.MkdirAll(opts.OutDir, 0o755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(opts.OutDir, file.name), []byte(file.contents.String()), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configureExportClient configures the provider exactly as Terraform would for
+// a provider block that only sets host and username
+func configureExportClient(ctx context.Context, opts ExportOptions) (*BMTLClientData, error) {
+	p := New(opts.Version)()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, typ := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	if opts.Host != "" {
+		values["host"] = tftypes.NewValue(tftypes.String, opts.Host)
+	}
+	if opts.Username != "" {
+		values["username"] = tftypes.NewValue(tftypes.String, opts.Username)
+	}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}, &resp)
+	if err := diagnosticsError(resp.Diagnostics); err != nil {
+		return nil, err
+	}
+
+	client := resp.ResourceData.(*BMTLClientData)
+	if !client.Authenticated() {
+		return nil, errors.New("export requires credentials, set BMLT_USERNAME and BMLT_PASSWORD, BMLT_ACCESS_TOKEN or BMLT_CREDENTIAL_HELPER")
+	}
+	return client, nil
+}
+
+func (e *exporter) exportProvider(ctx context.Context, b *strings.Builder) error {
+	fmt.Fprintf(b, `# Generated by terraform-provider-bmlt export. Credentials are read from the
+# BMLT_USERNAME and BMLT_PASSWORD environment variables or the other provider settings.
+terraform {
+  required_providers {
+    bmlt = {
+      source = "bmlt-enabled/bmlt"
+    }
+  }
+}
+
+provider "bmlt" {
+  host = %s
+}
+`, hclString(e.client.BaseURL.String()))
+	return nil
+}
+
+func (e *exporter) exportSettings(ctx context.Context, b *strings.Builder) error {
+	settings, httpResp, err := e.client.Client.RootServerAPI.GetSettings(e.client.Context).Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		return apiError("read settings", httpResp, err)
+	}
+
+	r := &SettingsResource{client: e.client}
+	data := &SettingsResourceModel{}
+	r.mapSettingsToModel(settings, data)
+
+	// Settings always exist on the server, so they are adopted on the first apply without an import block
+	b.WriteString("# Sensitive settings such as google_api_key are not exported and keep their current value.\n")
+	return e.writeResource(ctx, b, r, "bmlt_settings", "this", data, nil)
+}
+
+func (e *exporter) exportUsers(ctx context.Context, b *strings.Builder) error {
+	users, httpResp, err := e.client.Client.RootServerAPI.GetUsers(e.client.Context).Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		return apiError("read users", httpResp, err)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Id < users[j].Id })
+
+	used := make(map[string]bool)
+	for _, user := range users {
+		e.userNames[user.Id] = hclName(exportLabel(user.Username, "user", user.Id), used)
+	}
+
+	b.WriteString("# Passwords cannot be exported. Set password, password_wo or generate_password to manage them.\n")
+	r := &UserResource{client: e.client}
+	for _, user := range users {
+		data := &UserResourceModel{Keepers: types.MapNull(types.StringType)}
+		r.updateModelFromUser(data, &user)
+
+		references := map[string]string{}
+		if user.OwnerId != user.Id {
+			if reference, ok := e.reference("bmlt_user", e.userNames, user.OwnerId); ok {
+				references["owner_id"] = reference
+			}
+		}
+
+		if err := e.writeImportedResource(ctx, b, r, "bmlt_user", e.userNames[user.Id], user.Id, data, references); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportFormats(ctx context.Context, b *strings.Builder) error {
+	formats, httpResp, err := e.client.Client.RootServerAPI.GetFormats(e.client.Context).Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		return apiError("read formats", httpResp, err)
+	}
+	sort.Slice(formats, func(i, j int) bool { return formats[i].Id < formats[j].Id })
+
+	used := make(map[string]bool)
+	r := &FormatResource{client: e.client}
+	for _, format := range formats {
+		// Name formats after their key, preferring the default format key language
+		key := ""
+		for _, translation := range format.Translations {
+			if key == "" || translation.Language == defaultFormatKeysLanguage {
+				key = translation.Key
+			}
+		}
+		e.formatNames[format.Id] = hclName(exportLabel(key, "format", format.Id), used)
+
+		data := &FormatResourceModel{}
+		r.updateModelFromFormat(data, &format)
+
+		if err := e.writeImportedResource(ctx, b, r, "bmlt_format", e.formatNames[format.Id], format.Id, data, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportServiceBodies(ctx context.Context, b *strings.Builder) error {
+	var diags diag.Diagnostics
+	serviceBodies, ok := getServiceBodies(ctx, e.client, &diags)
+	if !ok {
+		return diagnosticsError(diags)
+	}
+	sort.Slice(serviceBodies, func(i, j int) bool { return serviceBodies[i].Id < serviceBodies[j].Id })
+
+	used := make(map[string]bool)
+	for _, serviceBody := range serviceBodies {
+		e.serviceBodyNames[serviceBody.Id] = hclName(exportLabel(serviceBody.Name, "service_body", serviceBody.Id), used)
+	}
+
+	r := &ServiceBodyResource{client: e.client}
+	for _, serviceBody := range serviceBodies {
+		data := &ServiceBodyResourceModel{}
+		r.updateModelFromServiceBody(data, &serviceBody)
+
+		references := map[string]string{
+			"assigned_user_ids": e.references("bmlt_user", e.userNames, serviceBody.AssignedUserIds),
+		}
+		if reference, ok := e.reference("bmlt_user", e.userNames, serviceBody.AdminUserId); ok {
+			references["admin_user_id"] = reference
+		}
+		if parentId := serviceBody.ParentId.Get(); parentId != nil {
+			if reference, ok := e.reference("bmlt_service_body", e.serviceBodyNames, *parentId); ok {
+				references["parent_id"] = reference
+			}
+		}
+
+		if err := e.writeImportedResource(ctx, b, r, "bmlt_service_body", e.serviceBodyNames[serviceBody.Id], serviceBody.Id, data, references); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportMeetings(ctx context.Context, b *strings.Builder) error {
+	var diags diag.Diagnostics
+	meetings, ok := getMeetings(ctx, e.client, meetingsFilter{}, &diags)
+	if !ok {
+		return diagnosticsError(diags)
+	}
+	sort.Slice(meetings, func(i, j int) bool { return meetings[i].Id < meetings[j].Id })
+
+	used := make(map[string]bool)
+	r := &MeetingResource{client: e.client}
+	for _, meeting := range meetings {
+		name := hclName(exportLabel(meeting.Name, "meeting", meeting.Id), used)
+
+		data := &MeetingResourceModel{}
+		r.updateModelFromMeeting(data, &meeting)
+		data.FormatKeys = types.SetNull(types.StringType)
+
+		references := map[string]string{
+			"format_ids": e.references("bmlt_format", e.formatNames, meeting.FormatIds),
+		}
+		if reference, ok := e.reference("bmlt_service_body", e.serviceBodyNames, meeting.ServiceBodyId); ok {
+			references["service_body_id"] = reference
+		}
+
+		if err := e.writeImportedResource(ctx, b, r, "bmlt_meeting", name, meeting.Id, data, references); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeImportedResource writes a resource block and records its import block
+func (e *exporter) writeImportedResource(ctx context.Context, b *strings.Builder, r resource.Resource, typeName, name string, id int32, model any, references map[string]string) error {
+	if err := e.writeResource(ctx, b, r, typeName, name, model, references); err != nil {
+		return err
+	}
+
+	if e.imports.Len() > 0 {
+		e.imports.WriteString("\n")
+	}
+	fmt.Fprintf(&e.imports, "import {\n  to = %s.%s\n  id = %s\n}\n", typeName, name, hclString(strconv.Itoa(int(id))))
+	return nil
+}
+
+// writeResource writes a resource block, separated from a previous block by a blank line
+func (e *exporter) writeResource(ctx context.Context, b *strings.Builder, r resource.Resource, typeName, name string, model any, references map[string]string) error {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	block, err := hclResourceBlock(ctx, schemaResp.Schema, typeName, name, model, references)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasSuffix(b.String(), "}\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString(block)
+	return nil
+}
+
+// reference returns a reference to the id of an exported resource
+func (e *exporter) reference(typeName string, names map[int32]string, id int32) (string, bool) {
+	name, ok := names[id]
+	if !ok {
+		return "", false
+	}
+	return typeName + "." + name + ".id", true
+}
+
+// references renders a list of IDs, referencing exported resources where possible
+func (e *exporter) references(typeName string, names map[int32]string, ids []int32) string {
+	sorted := append([]int32{}, ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	exprs := make([]string, 0, len(sorted))
+	for _, id := range sorted {
+		if reference, ok := e.reference(typeName, names, id); ok {
+			exprs = append(exprs, reference)
+		} else {
+			exprs = append(exprs, strconv.Itoa(int(id)))
+		}
+	}
+	return "[" + strings.Join(exprs, ", ") + "]"
+}
+
+// exportLabel returns the label an exported resource is named after, falling
+// back to the kind and ID of objects without one
+func exportLabel(label, kind string, id int32) string {
+	if strings.TrimSpace(label) == "" {
+		return fmt.Sprintf("%s_%d", kind, id)
+	}
+	return label
+}
+
+// diagnosticsError converts error diagnostics to an error
+func diagnosticsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return errors.Join(errs...)
+}
+
+// apiError converts a failed API call to an error in the format of addAPIError
+func apiError(action string, httpResp *http.Response, err error) error {
+	var diags diag.Diagnostics
+	addAPIError(&diags, action, httpResp, err, nil)
+	return diagnosticsError(diags)
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &FormatResource{}
+var _ list.ListResourceWithConfigure = &FormatResource{}
+
+func NewFormatListResource() list.ListResource {
+	return &FormatResource{}
+}
+
+type FormatListResourceModel struct {
+	Language types.String `tfsdk:"language"`
+}
+
+func (r *FormatResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists existing formats so they can be imported with terraform query",
+
+		Attributes: map[string]listschema.Attribute{
+			"language": listschema.StringAttribute{
+				MarkdownDescription: "Language of the translation key used as the display name of each format. Defaults to en.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *FormatResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config FormatListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	formats, httpResp, err := r.client.Client.RootServerAPI.GetFormats(r.client.Context).Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&diags, "read formats", httpResp, err, nil)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	language := config.Language.ValueString()
+	if language == "" {
+		language = defaultFormatKeysLanguage
+	}
+
+	stream.Results = streamListResults(req, formats, func(format bmlt.Format) list.ListResult {
+		result := req.NewListResult(ctx)
+		result.DisplayName = strconv.Itoa(int(format.Id))
+		for _, translation := range format.Translations {
+			if translation.Language == language {
+				result.DisplayName = translation.Key + " (" + translation.Name + ")"
+				break
+			}
+		}
+
+		data := &FormatResourceModel{Id: types.StringValue(strconv.Itoa(int(format.Id)))}
+		r.updateModelFromFormat(data, &format)
+
+		result.Diagnostics.Append(result.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
+		if req.IncludeResource {
+			result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+		}
+		return result
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/bmlt-enabled/bmlt-server-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -17,6 +18,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &FormatResource{}
 var _ resource.ResourceWithImportState = &FormatResource{}
+var _ resource.ResourceWithIdentity = &FormatResource{}
 
 func NewFormatResource() resource.Resource {
 	return &FormatResource{}
@@ -39,6 +41,10 @@ func (r *FormatResource) Metadata(ctx context.Context, req resource.MetadataRequ
 	resp.TypeName = req.ProviderTypeName + "_format"
 }
 
+func (r *FormatResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = objectIdentitySchema("Format identifier")
+}
+
 func (r *FormatResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
@@ -106,6 +112,10 @@ func (r *FormatResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
+	if !requireAuthentication(client, &resp.Diagnostics) {
+		return
+	}
+
 	r.client = client
 }
 
@@ -141,38 +151,19 @@ func (r *FormatResource) Create(ctx context.Context, req resource.CreateRequest,
 	// Create format
 	format, httpResp, err := r.client.Client.RootServerAPI.CreateFormat(r.client.Context).
 		FormatCreate(createRequest).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create format, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusCreated {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusCreated {
+		addAPIError(&resp.Diagnostics, "create format", httpResp, err, data)
 		return
 	}
+	r.client.invalidateFormatKeys()
 
 	// Map response back to model
 	data.Id = types.StringValue(strconv.Itoa(int(format.Id)))
-	data.WorldId = nullableString(format.WorldId)
-	data.Type = nullableString(format.Type)
-
-	// Update translations from response
-	var responseTranslations []FormatTranslationModel
-	if format.Translations != nil {
-		for _, t := range format.Translations {
-			translationModel := FormatTranslationModel{
-				Key:         types.StringValue(t.Key),
-				Name:        types.StringValue(t.Name),
-				Description: types.StringValue(t.Description),
-				Language:    types.StringValue(t.Language),
-			}
-			responseTranslations = append(responseTranslations, translationModel)
-		}
-	}
-	data.Translations = responseTranslations
+	r.updateModelFromFormat(data, format)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *FormatResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -194,43 +185,23 @@ func (r *FormatResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	// Get format from API
 	format, httpResp, err := r.client.Client.RootServerAPI.GetFormat(r.client.Context, id).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read format, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode == HTTPStatusNotFound {
+	if httpResp != nil && httpResp.StatusCode == HTTPStatusNotFound {
 		// Format was deleted outside of Terraform
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read format", httpResp, err, data)
 		return
 	}
 
 	// Map response to model
-	data.WorldId = nullableString(format.WorldId)
-	data.Type = nullableString(format.Type)
-
-	// Update translations
-	var translations []FormatTranslationModel
-	if format.Translations != nil {
-		for _, t := range format.Translations {
-			translationModel := FormatTranslationModel{
-				Key:         types.StringValue(t.Key),
-				Name:        types.StringValue(t.Name),
-				Description: types.StringValue(t.Description),
-				Language:    types.StringValue(t.Language),
-			}
-			translations = append(translations, translationModel)
-		}
-	}
-	data.Translations = translations
+	r.updateModelFromFormat(data, format)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *FormatResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -271,15 +242,11 @@ func (r *FormatResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Update format
 	httpResp, err := r.client.Client.RootServerAPI.UpdateFormat(r.client.Context, id).FormatUpdate(updateRequest).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update format, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusNoContent {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(&resp.Diagnostics, "update format", httpResp, err, data)
 		return
 	}
+	r.client.invalidateFormatKeys()
 
 	// Re-read the format to ensure state is consistent with server
 	formatId, err := strconv.ParseInt(data.Id.ValueString(), 10, 64)
@@ -289,38 +256,17 @@ func (r *FormatResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	updatedFormat, httpResp, err := r.client.Client.RootServerAPI.GetFormat(r.client.Context, formatId).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated format, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error",
-			fmt.Sprintf("API returned status %d when reading updated format", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read updated format", httpResp, err, data)
 		return
 	}
 
 	// Update all fields from the server response
-	data.WorldId = nullableString(updatedFormat.WorldId)
-	data.Type = nullableString(updatedFormat.Type)
-
-	// Update translations from response
-	var responseTranslations []FormatTranslationModel
-	if updatedFormat.Translations != nil {
-		for _, t := range updatedFormat.Translations {
-			translationModel := FormatTranslationModel{
-				Key:         types.StringValue(t.Key),
-				Name:        types.StringValue(t.Name),
-				Description: types.StringValue(t.Description),
-				Language:    types.StringValue(t.Language),
-			}
-			responseTranslations = append(responseTranslations, translationModel)
-		}
-	}
-	data.Translations = responseTranslations
+	r.updateModelFromFormat(data, updatedFormat)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *FormatResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -342,18 +288,68 @@ func (r *FormatResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	// Delete format
 	httpResp, err := r.client.Client.RootServerAPI.DeleteFormat(r.client.Context, id).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete format, got error: %s", err))
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(&resp.Diagnostics, "delete format", httpResp, err, data)
 		return
 	}
+	r.client.invalidateFormatKeys()
+}
 
-	if httpResp.StatusCode != HTTPStatusNoContent {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+// ImportState accepts the numeric format ID or key:<language>/<key>, or an import block identity
+func (r *FormatResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		importStateFromIdentity(ctx, r.client, req.Identity, resp)
+		return
+	}
+
+	languageKey, ok := strings.CutPrefix(req.ID, "key:")
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	language, key, ok := strings.Cut(languageKey, "/")
+	if !ok || language == "" || key == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a numeric format ID or key:<language>/<key> (e.g., key:en/BT), got: %q", req.ID),
+		)
 		return
 	}
+
+	formats, httpResp, err := r.client.Client.RootServerAPI.GetFormats(r.client.Context).Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read formats", httpResp, err, nil)
+		return
+	}
+
+	var matches []int32
+	for _, format := range formats {
+		for _, translation := range format.Translations {
+			if translation.Language == language && translation.Key == key {
+				matches = append(matches, format.Id)
+				break
+			}
+		}
+	}
+
+	setImportedID(ctx, req, resp, "Format", matches)
 }
 
-func (r *FormatResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
-	resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+// Helper function to update model from API response
+func (r *FormatResource) updateModelFromFormat(data *FormatResourceModel, format *bmlt.Format) {
+	data.WorldId = nullableString(format.WorldId)
+	data.Type = nullableString(format.Type)
+
+	var translations []FormatTranslationModel
+	for _, t := range format.Translations {
+		translations = append(translations, FormatTranslationModel{
+			Key:         types.StringValue(t.Key),
+			Name:        types.StringValue(t.Name),
+			Description: types.StringValue(t.Description),
+			Language:    types.StringValue(t.Language),
+		})
+	}
+	data.Translations = translations
 }
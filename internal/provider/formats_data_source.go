@@ -2,10 +2,11 @@ package provider
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/bmlt-enabled/bmlt-server-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -183,14 +184,8 @@ func (d *FormatsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Get formats from the API
-	formats, httpResp, err := d.client.Client.RootServerAPI.GetFormats(d.client.Context).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read formats, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	formats, ok := d.getFormats(ctx, data.Language.ValueString(), &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
@@ -251,3 +246,23 @@ func (d *FormatsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// getFormats returns formats from the admin API, or the public semantic API
+// when the provider was configured without credentials
+func (d *FormatsDataSource) getFormats(ctx context.Context, language string, diags *diag.Diagnostics) ([]bmlt.Format, bool) {
+	if !d.client.Authenticated() {
+		formats, err := getPublicFormats(ctx, d.client, language)
+		if err != nil {
+			diags.AddError("Client Error", "Unable to read formats, got error: "+err.Error())
+			return nil, false
+		}
+		return formats, true
+	}
+
+	formats, httpResp, err := d.client.Client.RootServerAPI.GetFormats(d.client.Context).Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(diags, "read formats", httpResp, err, nil)
+		return nil, false
+	}
+	return formats, true
+}
@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// hclIdentifierRegex matches the names HCL accepts unquoted as object keys
+var hclIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// hclAttribute is a rendered attribute of a block or object
+type hclAttribute struct {
+	name string
+	expr string
+}
+
+// hclResourceBlock renders a resource block from a resource model. Attributes
+// are taken from the resource schema: read-only, sensitive and null attributes
+// are left out, and references replace the value of the attributes they name.
+func hclResourceBlock(ctx context.Context, resourceSchema schema.Schema, typeName, name string, model any, references map[string]string) (string, error) {
+	state := tfsdk.State{Schema: resourceSchema}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		return "", fmt.Errorf("unable to convert %s.%s: %s", typeName, name, diags[0].Detail())
+	}
+
+	var values map[string]tftypes.Value
+	if err := state.Raw.As(&values); err != nil {
+		return "", err
+	}
+
+	var attributes []hclAttribute
+	for attrName, attribute := range resourceSchema.GetAttributes() {
+		if (attribute.IsComputed() && !attribute.IsOptional()) || attribute.IsWriteOnly() || attribute.IsSensitive() {
+			continue
+		}
+
+		if reference, ok := references[attrName]; ok {
+			attributes = append(attributes, hclAttribute{attrName, reference})
+			continue
+		}
+
+		value := values[attrName]
+		if value.IsNull() {
+			continue
+		}
+
+		expr, err := hclValue(value, "  ")
+		if err != nil {
+			return "", fmt.Errorf("unable to convert %s of %s.%s: %w", attrName, typeName, name, err)
+		}
+		attributes = append(attributes, hclAttribute{attrName, expr})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "resource %s %s {\n", hclString(typeName), hclString(name))
+	b.WriteString(hclBody(attributes, "  "))
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// hclBody renders attributes sorted by name in the layout of terraform fmt:
+// single line attributes first with aligned equals signs, then multi-line ones
+func hclBody(attributes []hclAttribute, indent string) string {
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].name < attributes[j].name
+	})
+
+	var singleLine, multiLine []hclAttribute
+	width := 0
+	for _, attribute := range attributes {
+		if strings.Contains(attribute.expr, "\n") {
+			multiLine = append(multiLine, attribute)
+			continue
+		}
+		singleLine = append(singleLine, attribute)
+		width = max(width, len(attribute.name))
+	}
+
+	var b strings.Builder
+	for _, attribute := range singleLine {
+		fmt.Fprintf(&b, "%s%-*s = %s\n", indent, width, attribute.name, attribute.expr)
+	}
+	for _, attribute := range multiLine {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s%s = %s\n", indent, attribute.name, attribute.expr)
+	}
+	return b.String()
+}
+
+// hclValue renders a Terraform value as an HCL expression. Nested values are
+// indented one level deeper than indent.
+func hclValue(value tftypes.Value, indent string) (string, error) {
+	typ := value.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		if err := value.As(&s); err != nil {
+			return "", err
+		}
+		return hclString(s), nil
+
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := value.As(n); err != nil {
+			return "", err
+		}
+		if n.IsInt() {
+			return n.Text('f', -1), nil
+		}
+		// The server stores coordinates with single precision, so values that are
+		// exact float32 values are written as the shortest float32 representation
+		if f, _ := n.Float64(); float64(float32(f)) == f {
+			return strconv.FormatFloat(f, 'f', -1, 32), nil
+		}
+		return n.Text('f', -1), nil
+
+	case typ.Is(tftypes.Bool):
+		var v bool
+		if err := value.As(&v); err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(v), nil
+
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return "", err
+		}
+
+		exprs := make([]string, 0, len(elements))
+		multiLine := false
+		for _, element := range elements {
+			expr, err := hclValue(element, indent+"  ")
+			if err != nil {
+				return "", err
+			}
+			multiLine = multiLine || strings.Contains(expr, "\n")
+			exprs = append(exprs, expr)
+		}
+
+		// Set elements have no order, sort them so exports are reproducible
+		if typ.Is(tftypes.Set{}) {
+			sortExpressions(exprs)
+		}
+
+		if !multiLine {
+			return "[" + strings.Join(exprs, ", ") + "]", nil
+		}
+
+		var b strings.Builder
+		b.WriteString("[\n")
+		for _, expr := range exprs {
+			fmt.Fprintf(&b, "%s  %s,\n", indent, expr)
+		}
+		b.WriteString(indent + "]")
+		return b.String(), nil
+
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return "", err
+		}
+		if len(elements) == 0 {
+			return "{}", nil
+		}
+
+		attributes := make([]hclAttribute, 0, len(elements))
+		for key, element := range elements {
+			if element.IsNull() {
+				continue
+			}
+			expr, err := hclValue(element, indent+"  ")
+			if err != nil {
+				return "", err
+			}
+			if !hclIdentifierRegex.MatchString(key) {
+				key = hclString(key)
+			}
+			attributes = append(attributes, hclAttribute{key, expr})
+		}
+		return "{\n" + hclBody(attributes, indent+"  ") + indent + "}", nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", typ)
+}
+
+// hclString quotes a string as an HCL template literal, escaping template
+// sequences so the value is used verbatim
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case !unicode.IsPrint(r):
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// hclName converts a label such as a meeting name into a Terraform resource
+// name, adding a numeric suffix when the name is already used
+func hclName(label string, used map[string]bool) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(label) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+
+	name := strings.TrimSuffix(b.String(), "_")
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// sortExpressions sorts rendered expressions, ordering numbers numerically
+func sortExpressions(exprs []string) {
+	sort.SliceStable(exprs, func(i, j int) bool {
+		a, errA := strconv.ParseFloat(exprs[i], 64)
+		b, errB := strconv.ParseFloat(exprs[j], 64)
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return exprs[i] < exprs[j]
+	})
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ServerIdentityModel identifies a singleton object of a root server
+type ServerIdentityModel struct {
+	Host types.String `tfsdk:"host"`
+}
+
+// ObjectIdentityModel identifies an object of a root server by its numeric ID
+type ObjectIdentityModel struct {
+	Host types.String `tfsdk:"host"`
+	Id   types.Int64  `tfsdk:"id"`
+}
+
+// hostIdentityAttribute describes the root server host of an identity
+var hostIdentityAttribute = identityschema.StringAttribute{
+	Description:       "Host name, and port if not the default, of the BMLT root server. Defaults to the host of the provider configuration when importing.",
+	OptionalForImport: true,
+}
+
+// objectIdentitySchema returns the identity schema of objects identified by
+// host and numeric ID
+func objectIdentitySchema(description string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"host": hostIdentityAttribute,
+			"id": identityschema.Int64Attribute{
+				Description:       description,
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// host returns the identity host of the configured root server
+func (c *BMTLClientData) host() types.String {
+	return types.StringValue(c.BaseURL.Host)
+}
+
+// objectIdentity builds the identity of an object from its string state ID
+func (c *BMTLClientData) objectIdentity(id types.String) *ObjectIdentityModel {
+	identity := &ObjectIdentityModel{Host: c.host(), Id: types.Int64Null()}
+	if n, err := strconv.ParseInt(id.ValueString(), 10, 64); err == nil {
+		identity.Id = types.Int64Value(n)
+	}
+	return identity
+}
+
+// importStateFromIdentity sets the state ID from the id attribute of an import
+// block identity. Identities of another root server are rejected so configurations
+// with several aliased providers cannot import an object from the wrong server.
+func importStateFromIdentity(ctx context.Context, client *BMTLClientData, identity *tfsdk.ResourceIdentity, resp *resource.ImportStateResponse) {
+	if !checkIdentityHost(ctx, client, identity, &resp.Diagnostics) {
+		return
+	}
+
+	var id types.Int64
+	resp.Diagnostics.Append(identity.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.FormatInt(id.ValueInt64(), 10))...)
+}
+
+// checkIdentityHost reports whether the host of an import block identity, when
+// set, is the host of the provider configuration
+func checkIdentityHost(ctx context.Context, client *BMTLClientData, identity *tfsdk.ResourceIdentity, diags *diag.Diagnostics) bool {
+	var host types.String
+	diags.Append(identity.GetAttribute(ctx, path.Root("host"), &host)...)
+	if diags.HasError() {
+		return false
+	}
+
+	if !host.IsNull() && host.ValueString() != client.BaseURL.Host {
+		diags.AddError(
+			"Identity Host Mismatch",
+			fmt.Sprintf("The identity host %q does not match the host of the provider configuration %q. "+
+				"Use the provider configured for that root server.", host.ValueString(), client.BaseURL.Host),
+		)
+		return false
+	}
+	return true
+}
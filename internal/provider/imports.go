@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// setImportedID stores the identifier of the single object matched by an
+// import ID lookup, reporting an error when no object or several objects match
+func setImportedID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, kind string, ids []int32) {
+	switch len(ids) {
+	case 0:
+		resp.Diagnostics.AddError(
+			kind+" Not Found",
+			fmt.Sprintf("No %s matches the import ID %q.", strings.ToLower(kind), req.ID),
+		)
+	case 1:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(int(ids[0])))...)
+	default:
+		matches := make([]string, 0, len(ids))
+		for _, id := range ids {
+			matches = append(matches, strconv.Itoa(int(id)))
+		}
+		resp.Diagnostics.AddError(
+			"Ambiguous Import ID",
+			fmt.Sprintf("The import ID %q matches more than one %s (IDs %s). Import one of them by its numeric ID instead.",
+				req.ID, strings.ToLower(kind), strings.Join(matches, ", ")),
+		)
+	}
+}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"iter"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// streamListResults converts each item to a list result, stopping once the
+// number of results requested by Terraform has been sent
+func streamListResults[T any](req list.ListRequest, items []T, toResult func(T) list.ListResult) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		for i, item := range items {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+			if !push(toResult(item)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &MeetingResource{}
+var _ list.ListResourceWithConfigure = &MeetingResource{}
+
+func NewMeetingListResource() list.ListResource {
+	return &MeetingResource{}
+}
+
+// MeetingListResourceModel describes the filters of the meeting list resource,
+// which are the same as those of the bmlt_meetings data source.
+type MeetingListResourceModel struct {
+	MeetingIds     types.String `tfsdk:"meeting_ids"`
+	Days           types.String `tfsdk:"days"`
+	ServiceBodyIds types.String `tfsdk:"service_body_ids"`
+	SearchString   types.String `tfsdk:"search_string"`
+}
+
+func (r *MeetingResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists existing meetings so they can be imported with terraform query",
+
+		Attributes: map[string]listschema.Attribute{
+			"meeting_ids": listschema.StringAttribute{
+				MarkdownDescription: "Comma delimited meeting ids to filter by",
+				Optional:            true,
+			},
+			"days": listschema.StringAttribute{
+				MarkdownDescription: "Comma delimited day ids between 0-6 to filter by",
+				Optional:            true,
+			},
+			"service_body_ids": listschema.StringAttribute{
+				MarkdownDescription: "Comma delimited service body ids to filter by",
+				Optional:            true,
+			},
+			"search_string": listschema.StringAttribute{
+				MarkdownDescription: "Search string to filter meetings",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *MeetingResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config MeetingListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	meetings, ok := getMeetings(ctx, r.client, meetingsFilter{
+		MeetingIds:     config.MeetingIds.ValueString(),
+		Days:           config.Days.ValueString(),
+		ServiceBodyIds: config.ServiceBodyIds.ValueString(),
+		SearchString:   config.SearchString.ValueString(),
+	}, &diags)
+	if !ok {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = streamListResults(req, meetings, func(meeting bmlt.Meeting) list.ListResult {
+		result := req.NewListResult(ctx)
+		result.DisplayName = meeting.Name
+
+		data := &MeetingResourceModel{Id: types.StringValue(strconv.Itoa(int(meeting.Id)))}
+		r.updateModelFromMeeting(data, &meeting)
+		// Generated configuration may only set one of format_ids and format_keys
+		data.FormatKeys = types.SetNull(types.StringType)
+
+		result.Diagnostics.Append(result.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
+		if req.IncludeResource {
+			result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+		}
+		return result
+	})
+}
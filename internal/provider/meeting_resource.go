@@ -4,18 +4,59 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = &MeetingResource{}
 var _ resource.ResourceWithImportState = &MeetingResource{}
+var _ resource.ResourceWithIdentity = &MeetingResource{}
+var _ resource.ResourceWithValidateConfig = &MeetingResource{}
+var _ resource.ResourceWithUpgradeState = &MeetingResource{}
+var _ resource.ResourceWithModifyPlan = &MeetingResource{}
+
+// defaultFormatKeysLanguage is the translation language of format_keys when
+// format_keys_language is not set
+const defaultFormatKeysLanguage = "en"
+
+// minMeetingSearchLength is the shortest search string the server accepts
+const minMeetingSearchLength = 3
+
+// Meeting venue types as defined by the BMLT server
+const (
+	venueTypeInPerson = 1
+	venueTypeVirtual  = 2
+	venueTypeHybrid   = 3
+)
+
+// meetingLocationAttributes lists the attributes describing a meeting's physical location
+var meetingLocationAttributes = []string{
+	"location_text",
+	"location_info",
+	"location_street",
+	"location_neighborhood",
+	"location_city_subsection",
+	"location_municipality",
+	"location_sub_province",
+	"location_province",
+	"location_postal_code_1",
+	"location_nation",
+}
 
 func NewMeetingResource() resource.Resource {
 	return &MeetingResource{}
@@ -25,45 +66,63 @@ type MeetingResource struct {
 	client *BMTLClientData
 }
 
-// MeetingResourceModel describes the resource data model (focusing on key fields)
+// MeetingResourceModel describes the resource data model.
 type MeetingResourceModel struct {
-	Id                   types.String  `tfsdk:"id"`
-	ServiceBodyId        types.Int64   `tfsdk:"service_body_id"`
-	FormatIds            []types.Int64 `tfsdk:"format_ids"`
-	VenueType            types.Int64   `tfsdk:"venue_type"`
-	TemporarilyVirtual   types.Bool    `tfsdk:"temporarily_virtual"`
-	Day                  types.Int64   `tfsdk:"day"`
-	StartTime            types.String  `tfsdk:"start_time"`
-	Duration             types.String  `tfsdk:"duration"`
-	TimeZone             types.String  `tfsdk:"time_zone"`
-	Latitude             types.Float64 `tfsdk:"latitude"`
-	Longitude            types.Float64 `tfsdk:"longitude"`
-	Published            types.Bool    `tfsdk:"published"`
-	Email                types.String  `tfsdk:"email"`
-	WorldId              types.String  `tfsdk:"world_id"`
-	Name                 types.String  `tfsdk:"name"`
-	LocationText         types.String  `tfsdk:"location_text"`
-	LocationInfo         types.String  `tfsdk:"location_info"`
-	LocationStreet       types.String  `tfsdk:"location_street"`
-	LocationMunicipality types.String  `tfsdk:"location_municipality"`
-	LocationProvince     types.String  `tfsdk:"location_province"`
-	LocationPostalCode1  types.String  `tfsdk:"location_postal_code_1"`
-	LocationNation       types.String  `tfsdk:"location_nation"`
-	VirtualMeetingLink   types.String  `tfsdk:"virtual_meeting_link"`
-	ContactName1         types.String  `tfsdk:"contact_name_1"`
-	ContactPhone1        types.String  `tfsdk:"contact_phone_1"`
-	ContactEmail1        types.String  `tfsdk:"contact_email_1"`
-	Comments             types.String  `tfsdk:"comments"`
-	AdminNotes           types.String  `tfsdk:"admin_notes"`
+	Id                           types.String  `tfsdk:"id"`
+	ServiceBodyId                types.Int64   `tfsdk:"service_body_id"`
+	FormatIds                    types.Set     `tfsdk:"format_ids"`
+	FormatKeys                   types.Set     `tfsdk:"format_keys"`
+	FormatKeysLanguage           types.String  `tfsdk:"format_keys_language"`
+	VenueType                    types.Int64   `tfsdk:"venue_type"`
+	TemporarilyVirtual           types.Bool    `tfsdk:"temporarily_virtual"`
+	Day                          types.Int64   `tfsdk:"day"`
+	StartTime                    types.String  `tfsdk:"start_time"`
+	Duration                     types.String  `tfsdk:"duration"`
+	TimeZone                     types.String  `tfsdk:"time_zone"`
+	Latitude                     types.Float64 `tfsdk:"latitude"`
+	Longitude                    types.Float64 `tfsdk:"longitude"`
+	Published                    types.Bool    `tfsdk:"published"`
+	Email                        types.String  `tfsdk:"email"`
+	WorldId                      types.String  `tfsdk:"world_id"`
+	Name                         types.String  `tfsdk:"name"`
+	LocationText                 types.String  `tfsdk:"location_text"`
+	LocationInfo                 types.String  `tfsdk:"location_info"`
+	LocationStreet               types.String  `tfsdk:"location_street"`
+	LocationNeighborhood         types.String  `tfsdk:"location_neighborhood"`
+	LocationCitySubsection       types.String  `tfsdk:"location_city_subsection"`
+	LocationMunicipality         types.String  `tfsdk:"location_municipality"`
+	LocationSubProvince          types.String  `tfsdk:"location_sub_province"`
+	LocationProvince             types.String  `tfsdk:"location_province"`
+	LocationPostalCode1          types.String  `tfsdk:"location_postal_code_1"`
+	LocationNation               types.String  `tfsdk:"location_nation"`
+	PhoneMeetingNumber           types.String  `tfsdk:"phone_meeting_number"`
+	VirtualMeetingLink           types.String  `tfsdk:"virtual_meeting_link"`
+	VirtualMeetingAdditionalInfo types.String  `tfsdk:"virtual_meeting_additional_info"`
+	ContactName1                 types.String  `tfsdk:"contact_name_1"`
+	ContactName2                 types.String  `tfsdk:"contact_name_2"`
+	ContactPhone1                types.String  `tfsdk:"contact_phone_1"`
+	ContactPhone2                types.String  `tfsdk:"contact_phone_2"`
+	ContactEmail1                types.String  `tfsdk:"contact_email_1"`
+	ContactEmail2                types.String  `tfsdk:"contact_email_2"`
+	BusLines                     types.String  `tfsdk:"bus_lines"`
+	TrainLines                   types.String  `tfsdk:"train_lines"`
+	Comments                     types.String  `tfsdk:"comments"`
+	AdminNotes                   types.String  `tfsdk:"admin_notes"`
+	CustomFields                 types.Map     `tfsdk:"custom_fields"`
 }
 
 func (r *MeetingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_meeting"
 }
 
+func (r *MeetingResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = objectIdentitySchema("Meeting identifier")
+}
+
 func (r *MeetingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Meeting resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -77,42 +136,79 @@ func (r *MeetingResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Service body identifier",
 				Required:            true,
 			},
-			"format_ids": schema.ListAttribute{
-				MarkdownDescription: "List of format identifiers",
-				Required:            true,
+			"format_ids": schema.SetAttribute{
+				MarkdownDescription: "Set of format identifiers. Exactly one of format_ids or format_keys must be set; format_ids is computed when format_keys is used.",
+				Optional:            true,
+				Computed:            true,
 				ElementType:         types.Int64Type,
+				Validators: []validator.Set{
+					setvalidator.ExactlyOneOf(path.MatchRoot("format_keys")),
+				},
+			},
+			"format_keys": schema.SetAttribute{
+				MarkdownDescription: "Set of format keys (e.g., O, BT, VM) in the format_keys_language translation. " +
+					"Keys are resolved to format identifiers by the server's formats, so the same configuration works against servers with different format identifiers. " +
+					"Null when format_ids is used.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"format_keys_language": schema.StringAttribute{
+				MarkdownDescription: "Language of the format translations used by format_keys. Defaults to en.",
+				Optional:            true,
 			},
 			"venue_type": schema.Int64Attribute{
 				MarkdownDescription: "Venue type (1=in-person, 2=virtual, 3=hybrid)",
 				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 3),
+				},
 			},
 			"temporarily_virtual": schema.BoolAttribute{
-				MarkdownDescription: "Whether the meeting is temporarily virtual",
+				MarkdownDescription: "Whether the meeting is temporarily virtual. Only valid for virtual meetings (venue_type = 2) that keep their physical location",
 				Optional:            true,
 			},
 			"day": schema.Int64Attribute{
 				MarkdownDescription: "Day of the week (0=Sunday, 1=Monday, etc.)",
 				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 6),
+				},
 			},
 			"start_time": schema.StringAttribute{
-				MarkdownDescription: "Meeting start time (HH:MM format)",
+				MarkdownDescription: "Meeting start time (HH:MM or HH:MM:SS format)",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(timeOfDayRegex, "must be in HH:MM or HH:MM:SS format"),
+				},
 			},
 			"duration": schema.StringAttribute{
-				MarkdownDescription: "Meeting duration (HH:MM format)",
+				MarkdownDescription: "Meeting duration (HH:MM or HH:MM:SS format)",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(timeOfDayRegex, "must be in HH:MM or HH:MM:SS format"),
+				},
 			},
 			"time_zone": schema.StringAttribute{
-				MarkdownDescription: "Time zone (e.g., America/New_York)",
+				MarkdownDescription: "IANA time zone name (e.g., America/New_York)",
 				Optional:            true,
+				Validators: []validator.String{
+					validTimeZone(),
+				},
 			},
 			"latitude": schema.Float64Attribute{
-				MarkdownDescription: "Latitude coordinate",
+				MarkdownDescription: "Latitude coordinate (-90 to 90)",
 				Required:            true,
+				Validators: []validator.Float64{
+					float64validator.Between(-90, 90),
+				},
 			},
 			"longitude": schema.Float64Attribute{
-				MarkdownDescription: "Longitude coordinate",
+				MarkdownDescription: "Longitude coordinate (-180 to 180)",
 				Required:            true,
+				Validators: []validator.Float64{
+					float64validator.Between(-180, 180),
+				},
 			},
 			"published": schema.BoolAttribute{
 				MarkdownDescription: "Whether the meeting is published",
@@ -142,10 +238,22 @@ func (r *MeetingResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Street address",
 				Optional:            true,
 			},
+			"location_neighborhood": schema.StringAttribute{
+				MarkdownDescription: "Neighborhood",
+				Optional:            true,
+			},
+			"location_city_subsection": schema.StringAttribute{
+				MarkdownDescription: "City subsection",
+				Optional:            true,
+			},
 			"location_municipality": schema.StringAttribute{
 				MarkdownDescription: "Municipality",
 				Optional:            true,
 			},
+			"location_sub_province": schema.StringAttribute{
+				MarkdownDescription: "Sub province",
+				Optional:            true,
+			},
 			"location_province": schema.StringAttribute{
 				MarkdownDescription: "Province",
 				Optional:            true,
@@ -158,22 +266,50 @@ func (r *MeetingResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Nation",
 				Optional:            true,
 			},
+			"phone_meeting_number": schema.StringAttribute{
+				MarkdownDescription: "Phone meeting number (dial-in number for phone meetings)",
+				Optional:            true,
+			},
 			"virtual_meeting_link": schema.StringAttribute{
 				MarkdownDescription: "Virtual meeting link",
 				Optional:            true,
 			},
+			"virtual_meeting_additional_info": schema.StringAttribute{
+				MarkdownDescription: "Additional virtual meeting info",
+				Optional:            true,
+			},
 			"contact_name_1": schema.StringAttribute{
 				MarkdownDescription: "Primary contact name",
 				Optional:            true,
 			},
+			"contact_name_2": schema.StringAttribute{
+				MarkdownDescription: "Secondary contact name",
+				Optional:            true,
+			},
 			"contact_phone_1": schema.StringAttribute{
 				MarkdownDescription: "Primary contact phone",
 				Optional:            true,
 			},
+			"contact_phone_2": schema.StringAttribute{
+				MarkdownDescription: "Secondary contact phone",
+				Optional:            true,
+			},
 			"contact_email_1": schema.StringAttribute{
 				MarkdownDescription: "Primary contact email",
 				Optional:            true,
 			},
+			"contact_email_2": schema.StringAttribute{
+				MarkdownDescription: "Secondary contact email",
+				Optional:            true,
+			},
+			"bus_lines": schema.StringAttribute{
+				MarkdownDescription: "Bus lines",
+				Optional:            true,
+			},
+			"train_lines": schema.StringAttribute{
+				MarkdownDescription: "Train lines",
+				Optional:            true,
+			},
 			"comments": schema.StringAttribute{
 				MarkdownDescription: "Comments",
 				Optional:            true,
@@ -182,6 +318,11 @@ func (r *MeetingResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Admin notes (not visible to end users)",
 				Optional:            true,
 			},
+			"custom_fields": schema.MapAttribute{
+				MarkdownDescription: "Server-specific custom meeting fields, keyed by field name. Only the configured fields are managed, other custom fields keep the values set on the server.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
 		},
 	}
 }
@@ -201,6 +342,10 @@ func (r *MeetingResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
+	if !requireAuthentication(client, &resp.Diagnostics) {
+		return
+	}
+
 	r.client = client
 }
 
@@ -212,53 +357,18 @@ func (r *MeetingResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	// Convert format IDs
-	var formatIds []int32
-	for _, id := range data.FormatIds {
-		formatIds = append(formatIds, safeInt64ToInt32(id.ValueInt64()))
-	}
-
-	// Convert model to API request
-	createRequest := bmlt.MeetingCreate{
-		ServiceBodyId:        safeInt64ToInt32(data.ServiceBodyId.ValueInt64()),
-		FormatIds:            formatIds,
-		VenueType:            safeInt64ToInt32(data.VenueType.ValueInt64()),
-		TemporarilyVirtual:   data.TemporarilyVirtual.ValueBoolPointer(),
-		Day:                  safeInt64ToInt32(data.Day.ValueInt64()),
-		StartTime:            data.StartTime.ValueString(),
-		Duration:             data.Duration.ValueString(),
-		TimeZone:             data.TimeZone.ValueStringPointer(),
-		Latitude:             float32(data.Latitude.ValueFloat64()),
-		Longitude:            float32(data.Longitude.ValueFloat64()),
-		Published:            data.Published.ValueBool(),
-		Email:                data.Email.ValueStringPointer(),
-		WorldId:              data.WorldId.ValueStringPointer(),
-		Name:                 data.Name.ValueString(),
-		LocationText:         data.LocationText.ValueStringPointer(),
-		LocationInfo:         data.LocationInfo.ValueStringPointer(),
-		LocationStreet:       data.LocationStreet.ValueStringPointer(),
-		LocationMunicipality: data.LocationMunicipality.ValueStringPointer(),
-		LocationProvince:     data.LocationProvince.ValueStringPointer(),
-		LocationPostalCode1:  data.LocationPostalCode1.ValueStringPointer(),
-		LocationNation:       data.LocationNation.ValueStringPointer(),
-		VirtualMeetingLink:   data.VirtualMeetingLink.ValueStringPointer(),
-		ContactName1:         data.ContactName1.ValueStringPointer(),
-		ContactPhone1:        data.ContactPhone1.ValueStringPointer(),
-		ContactEmail1:        data.ContactEmail1.ValueStringPointer(),
-		Comments:             data.Comments.ValueStringPointer(),
-		AdminNotes:           data.AdminNotes.ValueStringPointer(),
+	formatIds := r.planFormatIds(ctx, data, &resp.Diagnostics)
+	createRequest := meetingCreateRequest(data, formatIds)
+	createRequest.CustomFields = customFieldsFromModel(ctx, data.CustomFields, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Create meeting
 	meeting, httpResp, err := r.client.Client.RootServerAPI.CreateMeeting(r.client.Context).
 		MeetingCreate(createRequest).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create meeting, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusCreated {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusCreated {
+		addAPIError(&resp.Diagnostics, "create meeting", httpResp, err, data)
 		return
 	}
 
@@ -269,6 +379,7 @@ func (r *MeetingResource) Create(ctx context.Context, req resource.CreateRequest
 	r.updateModelFromMeeting(data, meeting)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *MeetingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -286,29 +397,36 @@ func (r *MeetingResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	meeting, httpResp, err := r.client.Client.RootServerAPI.GetMeeting(r.client.Context, id).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read meeting, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode == HTTPStatusNotFound {
+	if httpResp != nil && httpResp.StatusCode == HTTPStatusNotFound {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read meeting", httpResp, err, data)
 		return
 	}
 
 	r.updateModelFromMeeting(data, meeting)
+
+	// format_keys is only refreshed when the configuration uses it
+	if !data.FormatKeys.IsNull() {
+		formats := r.client.formatKeys(data.FormatKeysLanguage, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.FormatKeys = formats.keysValue(meeting.FormatIds)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *MeetingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *MeetingResourceModel
+	var data, state *MeetingResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -319,51 +437,17 @@ func (r *MeetingResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// Convert format IDs
-	var formatIds []int32
-	for _, id := range data.FormatIds {
-		formatIds = append(formatIds, safeInt64ToInt32(id.ValueInt64()))
-	}
-
-	updateRequest := bmlt.MeetingUpdate{
-		ServiceBodyId:        safeInt64ToInt32(data.ServiceBodyId.ValueInt64()),
-		FormatIds:            formatIds,
-		VenueType:            safeInt64ToInt32(data.VenueType.ValueInt64()),
-		TemporarilyVirtual:   data.TemporarilyVirtual.ValueBoolPointer(),
-		Day:                  safeInt64ToInt32(data.Day.ValueInt64()),
-		StartTime:            data.StartTime.ValueString(),
-		Duration:             data.Duration.ValueString(),
-		TimeZone:             data.TimeZone.ValueStringPointer(),
-		Latitude:             float32(data.Latitude.ValueFloat64()),
-		Longitude:            float32(data.Longitude.ValueFloat64()),
-		Published:            data.Published.ValueBool(),
-		Email:                data.Email.ValueStringPointer(),
-		WorldId:              data.WorldId.ValueStringPointer(),
-		Name:                 data.Name.ValueString(),
-		LocationText:         data.LocationText.ValueStringPointer(),
-		LocationInfo:         data.LocationInfo.ValueStringPointer(),
-		LocationStreet:       data.LocationStreet.ValueStringPointer(),
-		LocationMunicipality: data.LocationMunicipality.ValueStringPointer(),
-		LocationProvince:     data.LocationProvince.ValueStringPointer(),
-		LocationPostalCode1:  data.LocationPostalCode1.ValueStringPointer(),
-		LocationNation:       data.LocationNation.ValueStringPointer(),
-		VirtualMeetingLink:   data.VirtualMeetingLink.ValueStringPointer(),
-		ContactName1:         data.ContactName1.ValueStringPointer(),
-		ContactPhone1:        data.ContactPhone1.ValueStringPointer(),
-		ContactEmail1:        data.ContactEmail1.ValueStringPointer(),
-		Comments:             data.Comments.ValueStringPointer(),
-		AdminNotes:           data.AdminNotes.ValueStringPointer(),
+	formatIds := r.planFormatIds(ctx, data, &resp.Diagnostics)
+	updateRequest := bmlt.MeetingUpdate(meetingCreateRequest(data, formatIds))
+	updateRequest.CustomFields = customFieldsForUpdate(ctx, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	httpResp, err := r.client.Client.RootServerAPI.UpdateMeeting(r.client.Context, id).
 		MeetingUpdate(updateRequest).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update meeting, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusNoContent {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(&resp.Diagnostics, "update meeting", httpResp, err, data)
 		return
 	}
 
@@ -375,13 +459,8 @@ func (r *MeetingResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	updatedMeeting, httpResp, err := r.client.Client.RootServerAPI.GetMeeting(r.client.Context, meetingId).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated meeting, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d when reading updated meeting", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read updated meeting", httpResp, err, data)
 		return
 	}
 
@@ -389,6 +468,7 @@ func (r *MeetingResource) Update(ctx context.Context, req resource.UpdateRequest
 	r.updateModelFromMeeting(data, updatedMeeting)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *MeetingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -406,19 +486,298 @@ func (r *MeetingResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	httpResp, err := r.client.Client.RootServerAPI.DeleteMeeting(r.client.Context, id).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete meeting, got error: %s", err))
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(&resp.Diagnostics, "delete meeting", httpResp, err, data)
 		return
 	}
+}
 
-	if httpResp.StatusCode != HTTPStatusNoContent {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+// ValidateConfig enforces the BMLT venue type rules: in-person and hybrid meetings
+// need a physical location, virtual and hybrid meetings need a link or phone
+// number, and temporarily virtual meetings are virtual meetings that keep the
+// location they will return to.
+func (r *MeetingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var venueType types.Int64
+	var temporarilyVirtual types.Bool
+	var virtualMeetingLink, phoneMeetingNumber types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("venue_type"), &venueType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("temporarily_virtual"), &temporarilyVirtual)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("virtual_meeting_link"), &virtualMeetingLink)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("phone_meeting_number"), &phoneMeetingNumber)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Determine whether any location attribute is set, treating unknown values as set
+	hasLocation := false
+	for _, name := range meetingLocationAttributes {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		if value.IsUnknown() || value.ValueString() != "" {
+			hasLocation = true
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hasVirtualAccess := virtualMeetingLink.IsUnknown() || virtualMeetingLink.ValueString() != "" ||
+		phoneMeetingNumber.IsUnknown() || phoneMeetingNumber.ValueString() != ""
+
+	validateMeetingVenue(venueType, temporarilyVirtual, hasLocation, hasVirtualAccess, path.Empty(), &resp.Diagnostics)
 }
 
+// validateMeetingVenue checks that a meeting has the location and virtual meeting
+// details its venue type requires. base is the path of the meeting's attributes.
+func validateMeetingVenue(venueType types.Int64, temporarilyVirtual types.Bool, hasLocation, hasVirtualAccess bool, base path.Path, diags *diag.Diagnostics) {
+	// Venue type rules can only be checked once the venue type is known
+	if venueType.IsNull() || venueType.IsUnknown() {
+		return
+	}
+
+	switch venueType.ValueInt64() {
+	case venueTypeInPerson:
+		if !hasLocation {
+			diags.AddAttributeError(
+				base.AtName("location_street"),
+				"Missing Meeting Location",
+				"In-person meetings (venue_type = 1) require a physical location. "+
+					"Set location_street or another location_* attribute.",
+			)
+		}
+	case venueTypeVirtual:
+		if !hasVirtualAccess {
+			diags.AddAttributeError(
+				base.AtName("virtual_meeting_link"),
+				"Missing Virtual Meeting Details",
+				"Virtual meetings (venue_type = 2) require either virtual_meeting_link or phone_meeting_number.",
+			)
+		}
+		if temporarilyVirtual.ValueBool() && !hasLocation {
+			diags.AddAttributeError(
+				base.AtName("location_street"),
+				"Missing Meeting Location",
+				"Temporarily virtual meetings require the physical location the meeting will return to. "+
+					"Set location_street or another location_* attribute.",
+			)
+		}
+	case venueTypeHybrid:
+		if !hasLocation {
+			diags.AddAttributeError(
+				base.AtName("location_street"),
+				"Missing Meeting Location",
+				"Hybrid meetings (venue_type = 3) require a physical location. "+
+					"Set location_street or another location_* attribute.",
+			)
+		}
+		if !hasVirtualAccess {
+			diags.AddAttributeError(
+				base.AtName("virtual_meeting_link"),
+				"Missing Virtual Meeting Details",
+				"Hybrid meetings (venue_type = 3) require either virtual_meeting_link or phone_meeting_number.",
+			)
+		}
+	}
+
+	// Only virtual meetings can be temporarily virtual
+	if temporarilyVirtual.ValueBool() && venueType.ValueInt64() != venueTypeVirtual {
+		diags.AddAttributeError(
+			base.AtName("temporarily_virtual"),
+			"Invalid Temporarily Virtual Meeting",
+			fmt.Sprintf("temporarily_virtual can only be true for virtual meetings (venue_type = 2), got venue_type = %d. "+
+				"Set venue_type to 2 and keep the physical location while the meeting is held online.", venueType.ValueInt64()),
+		)
+	}
+}
+
+// ModifyPlan resolves format_keys to format_ids so plans show the identifiers.
+// Keys of formats that do not exist yet are resolved during apply instead.
+func (r *MeetingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state *MeetingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	var configKeys types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("format_keys"), &configKeys)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// format_keys stays null without reading the formats when format_ids is used
+	if configKeys.IsNull() {
+		plan.FormatKeys = types.SetNull(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	if plan.FormatKeys.IsUnknown() {
+		plan.FormatIds = types.SetUnknown(types.Int64Type)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	// Keep the computed identifiers from state when the keys did not change
+	if state != nil && plan.FormatKeysLanguage.Equal(state.FormatKeysLanguage) &&
+		plan.FormatKeys.Equal(state.FormatKeys) && !state.FormatIds.IsNull() {
+		plan.FormatIds = state.FormatIds
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	formats := r.client.formatKeys(plan.FormatKeysLanguage, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var keys []string
+	resp.Diagnostics.Append(plan.FormatKeys.ElementsAs(ctx, &keys, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	formatIds, missing := formats.formatIds(keys)
+	if len(missing) > 0 {
+		// The formats may be created during this apply
+		plan.FormatIds = types.SetUnknown(types.Int64Type)
+	} else {
+		plan.FormatIds = int64SetValue(formatIds)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *MeetingResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored format_ids as a list
+		0: listToSetStateUpgrader("format_ids"),
+	}
+}
+
+// ImportState accepts the numeric meeting ID, world_id:<world id>,
+// service_body:<service body id>/name:<meeting name>, or an import block identity
 func (r *MeetingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID == "" {
+		importStateFromIdentity(ctx, r.client, req.Identity, resp)
+		return
+	}
+
+	if _, err := strconv.ParseInt(req.ID, 10, 64); err == nil {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	worldId, isWorldId := strings.CutPrefix(req.ID, "world_id:")
+	serviceBodyName, isServiceBody := strings.CutPrefix(req.ID, "service_body:")
+	serviceBodyPart, name, _ := strings.Cut(serviceBodyName, "/name:")
+
+	var matches []int32
+	switch {
+	case isWorldId && worldId != "":
+		apiReq := r.client.Client.RootServerAPI.GetMeetings(r.client.Context)
+		// Shorter world IDs are only matched locally
+		if len(worldId) >= minMeetingSearchLength {
+			apiReq = apiReq.SearchString(worldId)
+		}
+		meetings, ok := r.findMeetings(apiReq, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+		for _, meeting := range meetings {
+			if meeting.WorldId == worldId {
+				matches = append(matches, meeting.Id)
+			}
+		}
+	case isServiceBody && name != "":
+		if _, err := strconv.ParseInt(serviceBodyPart, 10, 64); err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Invalid service body ID %q in import ID %q", serviceBodyPart, req.ID))
+			return
+		}
+
+		apiReq := r.client.Client.RootServerAPI.GetMeetings(r.client.Context).ServiceBodyIds(serviceBodyPart)
+		// Shorter names are only matched locally
+		if len(name) >= minMeetingSearchLength {
+			apiReq = apiReq.SearchString(name)
+		}
+		meetings, ok := r.findMeetings(apiReq, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+		for _, meeting := range meetings {
+			if meeting.Name == name {
+				matches = append(matches, meeting.Id)
+			}
+		}
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a numeric meeting ID, world_id:<world id> or service_body:<service body id>/name:<meeting name>, got: %q", req.ID),
+		)
+		return
+	}
+
+	setImportedID(ctx, req, resp, "Meeting", matches)
+}
+
+// findMeetings runs a meeting search for ImportState
+func (r *MeetingResource) findMeetings(apiReq bmlt.ApiGetMeetingsRequest, diags *diag.Diagnostics) ([]bmlt.Meeting, bool) {
+	meetings, httpResp, err := apiReq.Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(diags, "search meetings", httpResp, err, nil)
+		return nil, false
+	}
+	return meetings, true
+}
+
+// meetingCreateRequest converts a meeting model to an API request. Update
+// requests have the same fields.
+func meetingCreateRequest(data *MeetingResourceModel, formatIds []int32) bmlt.MeetingCreate {
+	return bmlt.MeetingCreate{
+		ServiceBodyId:                safeInt64ToInt32(data.ServiceBodyId.ValueInt64()),
+		FormatIds:                    formatIds,
+		VenueType:                    safeInt64ToInt32(data.VenueType.ValueInt64()),
+		TemporarilyVirtual:           data.TemporarilyVirtual.ValueBoolPointer(),
+		Day:                          safeInt64ToInt32(data.Day.ValueInt64()),
+		StartTime:                    data.StartTime.ValueString(),
+		Duration:                     data.Duration.ValueString(),
+		TimeZone:                     data.TimeZone.ValueStringPointer(),
+		Latitude:                     float32(data.Latitude.ValueFloat64()),
+		Longitude:                    float32(data.Longitude.ValueFloat64()),
+		Published:                    data.Published.ValueBool(),
+		Email:                        data.Email.ValueStringPointer(),
+		WorldId:                      data.WorldId.ValueStringPointer(),
+		Name:                         data.Name.ValueString(),
+		LocationText:                 data.LocationText.ValueStringPointer(),
+		LocationInfo:                 data.LocationInfo.ValueStringPointer(),
+		LocationStreet:               data.LocationStreet.ValueStringPointer(),
+		LocationNeighborhood:         data.LocationNeighborhood.ValueStringPointer(),
+		LocationCitySubsection:       data.LocationCitySubsection.ValueStringPointer(),
+		LocationMunicipality:         data.LocationMunicipality.ValueStringPointer(),
+		LocationSubProvince:          data.LocationSubProvince.ValueStringPointer(),
+		LocationProvince:             data.LocationProvince.ValueStringPointer(),
+		LocationPostalCode1:          data.LocationPostalCode1.ValueStringPointer(),
+		LocationNation:               data.LocationNation.ValueStringPointer(),
+		PhoneMeetingNumber:           data.PhoneMeetingNumber.ValueStringPointer(),
+		VirtualMeetingLink:           data.VirtualMeetingLink.ValueStringPointer(),
+		VirtualMeetingAdditionalInfo: data.VirtualMeetingAdditionalInfo.ValueStringPointer(),
+		ContactName1:                 data.ContactName1.ValueStringPointer(),
+		ContactName2:                 data.ContactName2.ValueStringPointer(),
+		ContactPhone1:                data.ContactPhone1.ValueStringPointer(),
+		ContactPhone2:                data.ContactPhone2.ValueStringPointer(),
+		ContactEmail1:                data.ContactEmail1.ValueStringPointer(),
+		ContactEmail2:                data.ContactEmail2.ValueStringPointer(),
+		BusLines:                     data.BusLines.ValueStringPointer(),
+		TrainLines:                   data.TrainLines.ValueStringPointer(),
+		Comments:                     data.Comments.ValueStringPointer(),
+		AdminNotes:                   data.AdminNotes.ValueStringPointer(),
+	}
 }
 
 // Helper function to update model from API response
@@ -439,21 +798,173 @@ func (r *MeetingResource) updateModelFromMeeting(data *MeetingResourceModel, mee
 	data.LocationText = types.StringPointerValue(meeting.LocationText)
 	data.LocationInfo = types.StringPointerValue(meeting.LocationInfo)
 	data.LocationStreet = types.StringPointerValue(meeting.LocationStreet)
+	data.LocationNeighborhood = types.StringPointerValue(meeting.LocationNeighborhood)
+	data.LocationCitySubsection = types.StringPointerValue(meeting.LocationCitySubsection)
 	data.LocationMunicipality = types.StringPointerValue(meeting.LocationMunicipality)
+	data.LocationSubProvince = types.StringPointerValue(meeting.LocationSubProvince)
 	data.LocationProvince = types.StringPointerValue(meeting.LocationProvince)
 	data.LocationPostalCode1 = types.StringPointerValue(meeting.LocationPostalCode1)
 	data.LocationNation = types.StringPointerValue(meeting.LocationNation)
+	data.PhoneMeetingNumber = types.StringPointerValue(meeting.PhoneMeetingNumber)
 	data.VirtualMeetingLink = types.StringPointerValue(meeting.VirtualMeetingLink)
+	data.VirtualMeetingAdditionalInfo = types.StringPointerValue(meeting.VirtualMeetingAdditionalInfo)
 	data.ContactName1 = types.StringPointerValue(meeting.ContactName1)
+	data.ContactName2 = types.StringPointerValue(meeting.ContactName2)
 	data.ContactPhone1 = types.StringPointerValue(meeting.ContactPhone1)
+	data.ContactPhone2 = types.StringPointerValue(meeting.ContactPhone2)
 	data.ContactEmail1 = types.StringPointerValue(meeting.ContactEmail1)
+	data.ContactEmail2 = types.StringPointerValue(meeting.ContactEmail2)
+	data.BusLines = types.StringPointerValue(meeting.BusLines)
+	data.TrainLines = types.StringPointerValue(meeting.TrainLines)
 	data.Comments = types.StringPointerValue(meeting.Comments)
 	data.AdminNotes = types.StringPointerValue(meeting.AdminNotes)
 
+	// Handle custom fields. Only the configured fields are tracked, so custom
+	// fields set in the server UI do not show up as drift and are not cleared.
+	if !data.CustomFields.IsNull() && !data.CustomFields.IsUnknown() {
+		customFields := make(map[string]attr.Value, len(data.CustomFields.Elements()))
+		for key := range data.CustomFields.Elements() {
+			value := ""
+			if meeting.CustomFields != nil {
+				value = (*meeting.CustomFields)[key]
+			}
+			customFields[key] = types.StringValue(value)
+		}
+		data.CustomFields = types.MapValueMust(types.StringType, customFields)
+	}
+
 	// Handle format IDs
-	var responseFormatIds []types.Int64
-	for _, formatId := range meeting.FormatIds {
-		responseFormatIds = append(responseFormatIds, types.Int64Value(int64(formatId)))
+	data.FormatIds = int64SetValue(meeting.FormatIds)
+}
+
+// meetingFormatKeys maps format identifiers to and from their translation keys in one language
+type meetingFormatKeys struct {
+	language string
+	ids      map[string]int32
+	keys     map[int32]string
+}
+
+// formatKeysCache holds the format keys of every language, read once per
+// provider instance instead of once per meeting
+type formatKeysCache struct {
+	mu         sync.Mutex
+	byLanguage map[string]*meetingFormatKeys
+}
+
+// newMeetingFormatKeys returns an empty format key index for a language
+func newMeetingFormatKeys(language string) *meetingFormatKeys {
+	return &meetingFormatKeys{
+		language: language,
+		ids:      make(map[string]int32),
+		keys:     make(map[int32]string),
+	}
+}
+
+// formatKeys returns the format keys in the given language. The formats are read
+// from the server on first use and kept until invalidateFormatKeys is called.
+func (c *BMTLClientData) formatKeys(language types.String, diags *diag.Diagnostics) *meetingFormatKeys {
+	lang := language.ValueString()
+	if lang == "" {
+		lang = defaultFormatKeysLanguage
+	}
+
+	c.formatKeysCache.mu.Lock()
+	defer c.formatKeysCache.mu.Unlock()
+
+	if c.formatKeysCache.byLanguage == nil {
+		formats, httpResp, err := c.Client.RootServerAPI.GetFormats(c.Context).Execute()
+		if err != nil || httpResp.StatusCode != HTTPStatusOK {
+			addAPIError(diags, "read formats", httpResp, err, nil)
+			return newMeetingFormatKeys(lang)
+		}
+
+		byLanguage := make(map[string]*meetingFormatKeys)
+		for _, format := range formats {
+			for _, translation := range format.Translations {
+				formatKeys, ok := byLanguage[translation.Language]
+				if !ok {
+					formatKeys = newMeetingFormatKeys(translation.Language)
+					byLanguage[translation.Language] = formatKeys
+				}
+				formatKeys.keys[format.Id] = translation.Key
+				// Keep the first format when several share a key
+				if _, ok := formatKeys.ids[translation.Key]; !ok {
+					formatKeys.ids[translation.Key] = format.Id
+				}
+			}
+		}
+		c.formatKeysCache.byLanguage = byLanguage
+	}
+
+	if formatKeys, ok := c.formatKeysCache.byLanguage[lang]; ok {
+		return formatKeys
+	}
+	return newMeetingFormatKeys(lang)
+}
+
+// invalidateFormatKeys discards the cached format keys after a format changed
+func (c *BMTLClientData) invalidateFormatKeys() {
+	c.formatKeysCache.mu.Lock()
+	defer c.formatKeysCache.mu.Unlock()
+	c.formatKeysCache.byLanguage = nil
+}
+
+// formatIds resolves format keys to identifiers, returning any keys that do not exist
+func (fk *meetingFormatKeys) formatIds(keys []string) ([]int32, []string) {
+	formatIds := make([]int32, 0, len(keys))
+	var missing []string
+	for _, key := range keys {
+		id, ok := fk.ids[key]
+		if !ok {
+			missing = append(missing, key)
+			continue
+		}
+		formatIds = append(formatIds, id)
+	}
+	return formatIds, missing
+}
+
+// planFormatIds returns the planned format identifiers, resolving format_keys when
+// the identifiers could not be determined while planning
+func (r *MeetingResource) planFormatIds(ctx context.Context, data *MeetingResourceModel, diags *diag.Diagnostics) []int32 {
+	var formatIds []int32
+	if !data.FormatIds.IsUnknown() {
+		diags.Append(data.FormatIds.ElementsAs(ctx, &formatIds, false)...)
+		return formatIds
+	}
+
+	var keys []string
+	diags.Append(data.FormatKeys.ElementsAs(ctx, &keys, false)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	formats := r.client.formatKeys(data.FormatKeysLanguage, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	formatIds, missing := formats.formatIds(keys)
+	if len(missing) > 0 {
+		diags.AddAttributeError(
+			path.Root("format_keys"),
+			"Unknown Format Key",
+			fmt.Sprintf("No format has a %q translation with the key(s) %s.", formats.language, strings.Join(missing, ", ")),
+		)
+	}
+	return formatIds
+}
+
+// keysValue converts format identifiers to a format_keys value. Formats
+// without a translation in the language are left out.
+func (fk *meetingFormatKeys) keysValue(formatIds []int32) types.Set {
+	keys := make([]attr.Value, 0, len(formatIds))
+	seen := make(map[string]bool)
+	for _, id := range formatIds {
+		if key, ok := fk.keys[id]; ok && !seen[key] {
+			seen[key] = true
+			keys = append(keys, types.StringValue(key))
+		}
 	}
-	data.FormatIds = responseFormatIds
+	return types.SetValueMust(types.StringType, keys)
 }
@@ -0,0 +1,935 @@
+package provider
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &MeetingScheduleResource{}
+var _ resource.ResourceWithImportState = &MeetingScheduleResource{}
+var _ resource.ResourceWithModifyPlan = &MeetingScheduleResource{}
+var _ resource.ResourceWithValidateConfig = &MeetingScheduleResource{}
+
+// meetingScheduleImportedPrivateKey is the private data key marking a schedule
+// that was imported and has not been read yet
+const meetingScheduleImportedPrivateKey = "imported"
+
+// meetingsCsvKeyColumn is the meetings_csv column holding the external key of a meeting
+const meetingsCsvKeyColumn = "key"
+
+func NewMeetingScheduleResource() resource.Resource {
+	return &MeetingScheduleResource{}
+}
+
+// MeetingScheduleResource manages the meetings of a service body as one set,
+// keyed by an external key instead of one bmlt_meeting per meeting
+type MeetingScheduleResource struct {
+	client *BMTLClientData
+	// meetings provides the request and format key handling of bmlt_meeting
+	meetings *MeetingResource
+}
+
+type MeetingScheduleResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	ServiceBodyId      types.Int64  `tfsdk:"service_body_id"`
+	FormatKeysLanguage types.String `tfsdk:"format_keys_language"`
+	Meetings           types.Map    `tfsdk:"meetings"`
+	MeetingsCsv        types.String `tfsdk:"meetings_csv"`
+}
+
+// MeetingScheduleItemModel is one meeting of a schedule. It has the attributes
+// of MeetingResourceModel that are not set by the schedule itself.
+type MeetingScheduleItemModel struct {
+	Id                           types.String  `tfsdk:"id"`
+	FormatKeys                   types.Set     `tfsdk:"format_keys"`
+	VenueType                    types.Int64   `tfsdk:"venue_type"`
+	TemporarilyVirtual           types.Bool    `tfsdk:"temporarily_virtual"`
+	Day                          types.Int64   `tfsdk:"day"`
+	StartTime                    types.String  `tfsdk:"start_time"`
+	Duration                     types.String  `tfsdk:"duration"`
+	TimeZone                     types.String  `tfsdk:"time_zone"`
+	Latitude                     types.Float64 `tfsdk:"latitude"`
+	Longitude                    types.Float64 `tfsdk:"longitude"`
+	Published                    types.Bool    `tfsdk:"published"`
+	Email                        types.String  `tfsdk:"email"`
+	WorldId                      types.String  `tfsdk:"world_id"`
+	Name                         types.String  `tfsdk:"name"`
+	LocationText                 types.String  `tfsdk:"location_text"`
+	LocationInfo                 types.String  `tfsdk:"location_info"`
+	LocationStreet               types.String  `tfsdk:"location_street"`
+	LocationNeighborhood         types.String  `tfsdk:"location_neighborhood"`
+	LocationCitySubsection       types.String  `tfsdk:"location_city_subsection"`
+	LocationMunicipality         types.String  `tfsdk:"location_municipality"`
+	LocationSubProvince          types.String  `tfsdk:"location_sub_province"`
+	LocationProvince             types.String  `tfsdk:"location_province"`
+	LocationPostalCode1          types.String  `tfsdk:"location_postal_code_1"`
+	LocationNation               types.String  `tfsdk:"location_nation"`
+	PhoneMeetingNumber           types.String  `tfsdk:"phone_meeting_number"`
+	VirtualMeetingLink           types.String  `tfsdk:"virtual_meeting_link"`
+	VirtualMeetingAdditionalInfo types.String  `tfsdk:"virtual_meeting_additional_info"`
+	ContactName1                 types.String  `tfsdk:"contact_name_1"`
+	ContactName2                 types.String  `tfsdk:"contact_name_2"`
+	ContactPhone1                types.String  `tfsdk:"contact_phone_1"`
+	ContactPhone2                types.String  `tfsdk:"contact_phone_2"`
+	ContactEmail1                types.String  `tfsdk:"contact_email_1"`
+	ContactEmail2                types.String  `tfsdk:"contact_email_2"`
+	BusLines                     types.String  `tfsdk:"bus_lines"`
+	TrainLines                   types.String  `tfsdk:"train_lines"`
+	Comments                     types.String  `tfsdk:"comments"`
+	AdminNotes                   types.String  `tfsdk:"admin_notes"`
+}
+
+func (r *MeetingScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_meeting_schedule"
+}
+
+func (r *MeetingScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Meeting schedule resource. Manages the meetings of a service body as a set keyed by a stable external key, " +
+			"such as the row identifiers of the spreadsheet the meeting list is maintained in. Meetings added to the schedule are created, " +
+			"changed meetings are updated and removed meetings are deleted. Meetings of the service body that are not in the schedule are left alone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Schedule identifier, the service body identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_body_id": schema.Int64Attribute{
+				MarkdownDescription: "Service body the meetings belong to. Changing it replaces the schedule, deleting its meetings and creating them in the new service body.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"format_keys_language": schema.StringAttribute{
+				MarkdownDescription: "Language of the format translations used by the format_keys of the meetings. Defaults to en.",
+				Optional:            true,
+			},
+			"meetings": schema.MapNestedAttribute{
+				MarkdownDescription: "Meetings keyed by external key. Exactly one of meetings or meetings_csv must be set, meetings is computed from meetings_csv. " +
+					"The attributes are those of bmlt_meeting, except that formats are only referenced by key.",
+				Optional: true,
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: meetingScheduleItemAttributes(ctx),
+				},
+				Validators: []validator.Map{
+					mapvalidator.ExactlyOneOf(path.MatchRoot("meetings_csv")),
+				},
+			},
+			"meetings_csv": schema.StringAttribute{
+				MarkdownDescription: "Meetings as CSV, e.g. `file(\"meetings.csv\")`. The header row names the columns after the meeting attributes, " +
+					"plus a `key` column with the external key. Empty cells leave an attribute unset, and format_keys cells list keys separated by spaces or commas.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// meetingScheduleItemAttributes returns the attributes of the meetings of a
+// schedule, which are those of bmlt_meeting without the attributes the schedule
+// sets itself
+func meetingScheduleItemAttributes(ctx context.Context) map[string]schema.Attribute {
+	var meetingSchema resource.SchemaResponse
+	NewMeetingResource().Schema(ctx, resource.SchemaRequest{}, &meetingSchema)
+
+	attributes := make(map[string]schema.Attribute)
+	for name, attribute := range meetingSchema.Schema.Attributes {
+		switch name {
+		case "service_body_id", "format_ids", "format_keys_language", "custom_fields":
+			continue
+		}
+		attributes[name] = attribute
+	}
+
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Meeting identifier",
+		Computed:            true,
+	}
+	attributes["format_keys"] = schema.SetAttribute{
+		MarkdownDescription: "Set of format keys (e.g., O, BT, VM) in the format_keys_language translation",
+		Optional:            true,
+		Computed:            true,
+		ElementType:         types.StringType,
+		Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+	}
+	// Spreadsheets rarely have these columns, so they have defaults in the schedule
+	attributes["published"] = schema.BoolAttribute{
+		MarkdownDescription: "Whether the meeting is published. Defaults to true.",
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(true),
+	}
+	attributes["temporarily_virtual"] = schema.BoolAttribute{
+		MarkdownDescription: "Whether the meeting is temporarily virtual. Only valid for virtual meetings (venue_type = 2) that keep their physical location. Defaults to false.",
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+	}
+	return attributes
+}
+
+// meetingScheduleItemType returns the object type of the meetings of a schedule
+func meetingScheduleItemType(ctx context.Context) types.ObjectType {
+	attrTypes := make(map[string]attr.Type)
+	for name, attribute := range meetingScheduleItemAttributes(ctx) {
+		attrTypes[name] = attribute.GetType()
+	}
+	return types.ObjectType{AttrTypes: attrTypes}
+}
+
+func (r *MeetingScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BMTLClientData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			clientTypeError(req.ProviderData),
+		)
+		return
+	}
+
+	if !requireAuthentication(client, &resp.Diagnostics) {
+		return
+	}
+
+	r.client = client
+	r.meetings = &MeetingResource{client: client}
+}
+
+func (r *MeetingScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *MeetingScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(strconv.FormatInt(data.ServiceBodyId.ValueInt64(), 10))
+	data.Meetings = r.reconcile(ctx, data, meetingScheduleItemsValue(ctx, nil, &resp.Diagnostics), &resp.Diagnostics)
+
+	// A schedule that fails to create is tainted and replaced by the next apply,
+	// which would give its meetings new IDs. The meetings created so far are
+	// deleted instead, so the next apply creates the schedule from scratch.
+	if resp.Diagnostics.HasError() {
+		items, ok := meetingScheduleItems(ctx, data.Meetings, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(items)) {
+			if r.deleteMeeting(key, items[key], &resp.Diagnostics) {
+				delete(items, key)
+			}
+		}
+
+		// Keep the meetings that could not be deleted in state, so replacing the
+		// tainted schedule deletes them
+		if len(items) == 0 {
+			return
+		}
+		data.Meetings = meetingScheduleItemsValue(ctx, items, &resp.Diagnostics)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MeetingScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *MeetingScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported schedules adopt all meetings of the service body on their first read
+	importedFlag, d := req.Private.GetKey(ctx, meetingScheduleImportedPrivateKey)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	imported := importedFlag != nil
+	if data.ServiceBodyId.IsNull() {
+		serviceBodyId, err := strconv.ParseInt(data.Id.ValueString(), 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Parse Error", fmt.Sprintf("Unable to parse service body ID: %s", err))
+			return
+		}
+		data.ServiceBodyId = types.Int64Value(serviceBodyId)
+	}
+
+	items, ok := meetingScheduleItems(ctx, data.Meetings, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	// Without any meetings to read, an unfiltered request would return every meeting
+	var meetings []bmlt.Meeting
+	if imported || len(items) > 0 {
+		apiReq := r.client.Client.RootServerAPI.GetMeetings(r.client.Context)
+		if imported {
+			apiReq = apiReq.ServiceBodyIds(strconv.FormatInt(data.ServiceBodyId.ValueInt64(), 10))
+		} else {
+			ids := make([]string, 0, len(items))
+			for _, key := range slices.Sorted(maps.Keys(items)) {
+				ids = append(ids, items[key].Id.ValueString())
+			}
+			apiReq = apiReq.MeetingIds(strings.Join(ids, ","))
+		}
+
+		var err error
+		var httpResp *http.Response
+		meetings, httpResp, err = apiReq.Execute()
+		if err != nil || httpResp.StatusCode != HTTPStatusOK {
+			addAPIError(&resp.Diagnostics, "read meetings", httpResp, err, nil)
+			return
+		}
+	}
+
+	// The formats are only read when there are meetings to refresh
+	var formats *meetingFormatKeys
+	if len(meetings) > 0 {
+		formats = r.client.formatKeys(data.FormatKeysLanguage, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	meetingsById := make(map[string]*bmlt.Meeting, len(meetings))
+	for i := range meetings {
+		id := strconv.Itoa(int(meetings[i].Id))
+		meetingsById[id] = &meetings[i]
+		if imported {
+			// Imported meetings are keyed by meeting ID
+			items[id] = MeetingScheduleItemModel{Id: types.StringValue(id)}
+		}
+	}
+
+	for key, item := range items {
+		meeting, ok := meetingsById[item.Id.ValueString()]
+		if !ok {
+			// Deleted outside of Terraform, the meeting is planned to be created again
+			delete(items, key)
+			continue
+		}
+		r.updateItemFromMeeting(&item, meeting, formats)
+		items[key] = item
+	}
+
+	data.Meetings = meetingScheduleItemsValue(ctx, items, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if imported {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, meetingScheduleImportedPrivateKey, nil)...)
+	}
+}
+
+func (r *MeetingScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *MeetingScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Meetings = r.reconcile(ctx, data, state.Meetings, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MeetingScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *MeetingScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, ok := meetingScheduleItems(ctx, data.Meetings, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(items)) {
+		if r.deleteMeeting(key, items[key], &resp.Diagnostics) {
+			delete(items, key)
+		}
+	}
+
+	// Keep the meetings that could not be deleted in state
+	if resp.Diagnostics.HasError() {
+		data.Meetings = meetingScheduleItemsValue(ctx, items, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+}
+
+// ValidateConfig checks the venue type rules of bmlt_meeting for each meeting.
+// Meetings from meetings_csv are checked in ModifyPlan once the CSV is parsed.
+func (r *MeetingScheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var meetings types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("meetings"), &meetings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateMeetingScheduleVenues(meetings, &resp.Diagnostics)
+}
+
+// ModifyPlan computes meetings from meetings_csv and keeps the identifiers of
+// meetings whose key is already in state, so only meetings that are added show
+// an unknown identifier
+func (r *MeetingScheduleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state *MeetingScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.MeetingsCsv.IsUnknown() {
+		plan.Meetings = types.MapUnknown(meetingScheduleItemType(ctx))
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+	if !plan.MeetingsCsv.IsNull() {
+		plan.Meetings = parseMeetingsCsv(ctx, plan.MeetingsCsv.ValueString(), &resp.Diagnostics)
+		validateMeetingScheduleVenues(plan.Meetings, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	items, ok := meetingScheduleItems(ctx, plan.Meetings, &resp.Diagnostics)
+	if !ok || plan.Meetings.IsNull() {
+		return
+	}
+
+	// Replacing the schedule creates all meetings again
+	stateItems := map[string]MeetingScheduleItemModel{}
+	if state != nil && plan.ServiceBodyId.Equal(state.ServiceBodyId) {
+		stateItems, ok = meetingScheduleItems(ctx, state.Meetings, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+	}
+
+	for key, item := range items {
+		if stateItem, ok := stateItems[key]; ok {
+			item.Id = stateItem.Id
+		} else {
+			item.Id = types.StringUnknown()
+		}
+		items[key] = item
+	}
+
+	plan.Meetings = meetingScheduleItemsValue(ctx, items, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// ImportState accepts the numeric service body ID. All meetings of the service
+// body are imported, keyed by their meeting ID.
+func (r *MeetingScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	serviceBodyId, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a numeric service body ID, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_body_id"), serviceBodyId)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, meetingScheduleImportedPrivateKey, []byte("true"))...)
+}
+
+// reconcile creates, updates and deletes meetings so the service body has the
+// planned meetings, and returns the meetings that exist afterwards. Failures
+// are reported per meeting and do not stop the other meetings from being applied.
+func (r *MeetingScheduleResource) reconcile(ctx context.Context, data *MeetingScheduleResourceModel, prior types.Map, diags *diag.Diagnostics) types.Map {
+	planItems, ok := meetingScheduleItems(ctx, data.Meetings, diags)
+	if !ok {
+		return prior
+	}
+	priorItems, ok := meetingScheduleItems(ctx, prior, diags)
+	if !ok {
+		return prior
+	}
+
+	// The formats are read when the first meeting is created or updated
+	var formats *meetingFormatKeys
+
+	result := maps.Clone(priorItems)
+
+	// Removed meetings are deleted first, so new meetings can take over their world IDs
+	for _, key := range slices.Sorted(maps.Keys(priorItems)) {
+		if _, ok := planItems[key]; ok {
+			continue
+		}
+		if r.deleteMeeting(key, priorItems[key], diags) {
+			delete(result, key)
+		}
+	}
+
+	planElements, priorElements := data.Meetings.Elements(), prior.Elements()
+	for _, key := range slices.Sorted(maps.Keys(planItems)) {
+		item := planItems[key]
+		priorItem, exists := priorItems[key]
+		if exists && planElements[key].Equal(priorElements[key]) {
+			continue
+		}
+
+		itemPath := path.Root("meetings").AtMapKey(key)
+
+		if formats == nil {
+			var formatDiags diag.Diagnostics
+			formats = r.client.formatKeys(data.FormatKeysLanguage, &formatDiags)
+			diags.Append(formatDiags...)
+			if formatDiags.HasError() {
+				break
+			}
+		}
+
+		var keys []string
+		diags.Append(item.FormatKeys.ElementsAs(ctx, &keys, false)...)
+		formatIds, missing := formats.formatIds(keys)
+		if len(missing) > 0 {
+			diags.AddAttributeError(
+				itemPath.AtName("format_keys"),
+				"Unknown Format Key",
+				fmt.Sprintf("No format has a %q translation with the key(s) %s.", formats.language, strings.Join(missing, ", ")),
+			)
+			continue
+		}
+
+		request := meetingCreateRequest(item.meetingModel(data.ServiceBodyId), formatIds)
+
+		if !exists {
+			meeting, httpResp, err := r.client.Client.RootServerAPI.CreateMeeting(r.client.Context).
+				MeetingCreate(request).Execute()
+			if err != nil || httpResp.StatusCode != HTTPStatusCreated {
+				addAPIErrorAt(diags, itemPath, fmt.Sprintf("create meeting %q", key), httpResp, err, item)
+				continue
+			}
+			item.Id = types.StringValue(strconv.Itoa(int(meeting.Id)))
+		} else {
+			item.Id = priorItem.Id
+			id, err := strconv.ParseInt(item.Id.ValueString(), 10, 64)
+			if err != nil {
+				diags.AddAttributeError(itemPath, "Parse Error", fmt.Sprintf("Unable to parse meeting ID: %s", err))
+				continue
+			}
+
+			httpResp, err := r.client.Client.RootServerAPI.UpdateMeeting(r.client.Context, id).
+				MeetingUpdate(bmlt.MeetingUpdate(request)).Execute()
+			if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+				addAPIErrorAt(diags, itemPath, fmt.Sprintf("update meeting %q", key), httpResp, err, item)
+				continue
+			}
+		}
+
+		// The planned values are kept so the state matches the plan, the next
+		// refresh picks up any normalization by the server
+		result[key] = item
+	}
+
+	return meetingScheduleItemsValue(ctx, result, diags)
+}
+
+// deleteMeeting deletes the meeting of a key, reporting whether it is gone
+func (r *MeetingScheduleResource) deleteMeeting(key string, item MeetingScheduleItemModel, diags *diag.Diagnostics) bool {
+	id, err := strconv.ParseInt(item.Id.ValueString(), 10, 64)
+	if err != nil {
+		diags.AddError("Parse Error", fmt.Sprintf("Unable to parse the ID of meeting %q: %s", key, err))
+		return false
+	}
+
+	httpResp, err := r.client.Client.RootServerAPI.DeleteMeeting(r.client.Context, id).Execute()
+	if httpResp != nil && httpResp.StatusCode == HTTPStatusNotFound {
+		return true
+	}
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(diags, fmt.Sprintf("delete meeting %q", key), httpResp, err, nil)
+		return false
+	}
+	return true
+}
+
+// updateItemFromMeeting refreshes an item from the server. The server stores
+// coordinates with single precision, so they are only refreshed when they
+// changed beyond that.
+func (r *MeetingScheduleResource) updateItemFromMeeting(item *MeetingScheduleItemModel, meeting *bmlt.Meeting, formats *meetingFormatKeys) {
+	latitude, longitude := item.Latitude, item.Longitude
+
+	data := item.meetingModel(types.Int64Value(int64(meeting.ServiceBodyId)))
+	r.meetings.updateModelFromMeeting(data, meeting)
+	item.updateFromMeetingModel(data)
+	item.FormatKeys = formats.keysValue(meeting.FormatIds)
+
+	if !latitude.IsNull() && float32(latitude.ValueFloat64()) == meeting.Latitude {
+		item.Latitude = latitude
+	}
+	if !longitude.IsNull() && float32(longitude.ValueFloat64()) == meeting.Longitude {
+		item.Longitude = longitude
+	}
+}
+
+// meetingModel converts the item to a bmlt_meeting model in the service body
+func (item *MeetingScheduleItemModel) meetingModel(serviceBodyId types.Int64) *MeetingResourceModel {
+	return &MeetingResourceModel{
+		Id:                           item.Id,
+		ServiceBodyId:                serviceBodyId,
+		FormatKeys:                   item.FormatKeys,
+		VenueType:                    item.VenueType,
+		TemporarilyVirtual:           item.TemporarilyVirtual,
+		Day:                          item.Day,
+		StartTime:                    item.StartTime,
+		Duration:                     item.Duration,
+		TimeZone:                     item.TimeZone,
+		Latitude:                     item.Latitude,
+		Longitude:                    item.Longitude,
+		Published:                    item.Published,
+		Email:                        item.Email,
+		WorldId:                      item.WorldId,
+		Name:                         item.Name,
+		LocationText:                 item.LocationText,
+		LocationInfo:                 item.LocationInfo,
+		LocationStreet:               item.LocationStreet,
+		LocationNeighborhood:         item.LocationNeighborhood,
+		LocationCitySubsection:       item.LocationCitySubsection,
+		LocationMunicipality:         item.LocationMunicipality,
+		LocationSubProvince:          item.LocationSubProvince,
+		LocationProvince:             item.LocationProvince,
+		LocationPostalCode1:          item.LocationPostalCode1,
+		LocationNation:               item.LocationNation,
+		PhoneMeetingNumber:           item.PhoneMeetingNumber,
+		VirtualMeetingLink:           item.VirtualMeetingLink,
+		VirtualMeetingAdditionalInfo: item.VirtualMeetingAdditionalInfo,
+		ContactName1:                 item.ContactName1,
+		ContactName2:                 item.ContactName2,
+		ContactPhone1:                item.ContactPhone1,
+		ContactPhone2:                item.ContactPhone2,
+		ContactEmail1:                item.ContactEmail1,
+		ContactEmail2:                item.ContactEmail2,
+		BusLines:                     item.BusLines,
+		TrainLines:                   item.TrainLines,
+		Comments:                     item.Comments,
+		AdminNotes:                   item.AdminNotes,
+	}
+}
+
+// updateFromMeetingModel copies the attributes of a bmlt_meeting model to the item
+func (item *MeetingScheduleItemModel) updateFromMeetingModel(data *MeetingResourceModel) {
+	item.VenueType = data.VenueType
+	item.TemporarilyVirtual = data.TemporarilyVirtual
+	item.Day = data.Day
+	item.StartTime = data.StartTime
+	item.Duration = data.Duration
+	item.TimeZone = data.TimeZone
+	item.Latitude = data.Latitude
+	item.Longitude = data.Longitude
+	item.Published = data.Published
+	item.Email = data.Email
+	item.WorldId = data.WorldId
+	item.Name = data.Name
+	item.LocationText = data.LocationText
+	item.LocationInfo = data.LocationInfo
+	item.LocationStreet = data.LocationStreet
+	item.LocationNeighborhood = data.LocationNeighborhood
+	item.LocationCitySubsection = data.LocationCitySubsection
+	item.LocationMunicipality = data.LocationMunicipality
+	item.LocationSubProvince = data.LocationSubProvince
+	item.LocationProvince = data.LocationProvince
+	item.LocationPostalCode1 = data.LocationPostalCode1
+	item.LocationNation = data.LocationNation
+	item.PhoneMeetingNumber = data.PhoneMeetingNumber
+	item.VirtualMeetingLink = data.VirtualMeetingLink
+	item.VirtualMeetingAdditionalInfo = data.VirtualMeetingAdditionalInfo
+	item.ContactName1 = data.ContactName1
+	item.ContactName2 = data.ContactName2
+	item.ContactPhone1 = data.ContactPhone1
+	item.ContactPhone2 = data.ContactPhone2
+	item.ContactEmail1 = data.ContactEmail1
+	item.ContactEmail2 = data.ContactEmail2
+	item.BusLines = data.BusLines
+	item.TrainLines = data.TrainLines
+	item.Comments = data.Comments
+	item.AdminNotes = data.AdminNotes
+}
+
+// meetingScheduleItems converts a meetings value to items. It reports false
+// without diagnostics when a meeting is unknown.
+func meetingScheduleItems(ctx context.Context, value types.Map, diags *diag.Diagnostics) (map[string]MeetingScheduleItemModel, bool) {
+	items := make(map[string]MeetingScheduleItemModel)
+	if value.IsNull() {
+		return items, true
+	}
+	if value.IsUnknown() {
+		return nil, false
+	}
+	for _, element := range value.Elements() {
+		if element.IsUnknown() {
+			return nil, false
+		}
+	}
+
+	diags.Append(value.ElementsAs(ctx, &items, false)...)
+	return items, !diags.HasError()
+}
+
+// meetingScheduleItemsValue converts items to a meetings value. It is never null.
+func meetingScheduleItemsValue(ctx context.Context, items map[string]MeetingScheduleItemModel, diags *diag.Diagnostics) types.Map {
+	if items == nil {
+		items = map[string]MeetingScheduleItemModel{}
+	}
+	value, d := types.MapValueFrom(ctx, meetingScheduleItemType(ctx), items)
+	diags.Append(d...)
+	return value
+}
+
+// validateMeetingScheduleVenues checks the venue type rules of bmlt_meeting for
+// each meeting of a meetings value, treating unknown values as set
+func validateMeetingScheduleVenues(meetings types.Map, diags *diag.Diagnostics) {
+	elements := meetings.Elements()
+	for _, key := range slices.Sorted(maps.Keys(elements)) {
+		meeting, ok := elements[key].(types.Object)
+		if !ok || meeting.IsNull() || meeting.IsUnknown() {
+			continue
+		}
+		attributes := meeting.Attributes()
+
+		hasLocation := false
+		for _, name := range meetingLocationAttributes {
+			hasLocation = hasLocation || isSetString(attributes[name])
+		}
+		hasVirtualAccess := isSetString(attributes["virtual_meeting_link"]) || isSetString(attributes["phone_meeting_number"])
+
+		venueType, _ := attributes["venue_type"].(types.Int64)
+		temporarilyVirtual, _ := attributes["temporarily_virtual"].(types.Bool)
+		validateMeetingVenue(venueType, temporarilyVirtual, hasLocation, hasVirtualAccess, path.Root("meetings").AtMapKey(key), diags)
+	}
+}
+
+// isSetString reports whether a string value is unknown or not empty
+func isSetString(value attr.Value) bool {
+	s, ok := value.(types.String)
+	return ok && (s.IsUnknown() || s.ValueString() != "")
+}
+
+// parseMeetingsCsv converts meetings_csv to a meetings value, applying the
+// defaults of the meeting attributes to empty cells and checking cells with the
+// validators of the attributes
+func parseMeetingsCsv(ctx context.Context, content string, diags *diag.Diagnostics) types.Map {
+	csvPath := path.Root("meetings_csv")
+	attributes := meetingScheduleItemAttributes(ctx)
+	itemType := meetingScheduleItemType(ctx)
+
+	// Spreadsheet applications commonly start UTF-8 CSV exports with a byte order mark
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(content, "\ufeff")))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		diags.AddAttributeError(csvPath, "Invalid Meetings CSV", fmt.Sprintf("Unable to parse meetings_csv: %s", err))
+		return types.MapNull(itemType)
+	}
+	if len(records) == 0 {
+		diags.AddAttributeError(csvPath, "Invalid Meetings CSV", "meetings_csv must start with a header row naming the columns.")
+		return types.MapNull(itemType)
+	}
+
+	header := records[0]
+	keyColumn := -1
+	seen := make(map[string]bool, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		header[i] = column
+		if seen[column] {
+			diags.AddAttributeError(csvPath, "Invalid Meetings CSV", fmt.Sprintf("The header row repeats the column %q.", column))
+			continue
+		}
+		seen[column] = true
+		if column == meetingsCsvKeyColumn {
+			keyColumn = i
+			continue
+		}
+		if attribute, ok := attributes[column]; !ok || !attribute.IsOptional() && !attribute.IsRequired() {
+			diags.AddAttributeError(csvPath, "Invalid Meetings CSV", fmt.Sprintf("Unknown column %q, columns must be named after meeting attributes.", column))
+		}
+	}
+	if keyColumn < 0 {
+		diags.AddAttributeError(csvPath, "Invalid Meetings CSV", fmt.Sprintf("meetings_csv has no %q column with the external key of each meeting.", meetingsCsvKeyColumn))
+	}
+	if diags.HasError() {
+		return types.MapNull(itemType)
+	}
+
+	elements := make(map[string]attr.Value, len(records)-1)
+	for n, record := range records[1:] {
+		line := n + 2
+
+		key := strings.TrimSpace(record[keyColumn])
+		if key == "" {
+			diags.AddAttributeError(csvPath, "Invalid Meetings CSV", fmt.Sprintf("Line %d has no %s.", line, meetingsCsvKeyColumn))
+			continue
+		}
+		if _, ok := elements[key]; ok {
+			diags.AddAttributeError(csvPath, "Invalid Meetings CSV", fmt.Sprintf("Line %d repeats the key %q.", line, key))
+			continue
+		}
+
+		values := make(map[string]attr.Value, len(attributes))
+		for name, attribute := range attributes {
+			values[name] = meetingsCsvDefault(ctx, attribute)
+		}
+		for i, cell := range record {
+			if i == keyColumn || strings.TrimSpace(cell) == "" {
+				continue
+			}
+			value, err := meetingsCsvValue(attributes[header[i]], cell)
+			if err != nil {
+				diags.AddAttributeError(csvPath, "Invalid Meetings CSV", fmt.Sprintf("Line %d, column %s: %s", line, header[i], err))
+				continue
+			}
+			validateMeetingsCsvValue(ctx, attributes[header[i]], value, path.Root("meetings").AtMapKey(key).AtName(header[i]), diags)
+			values[header[i]] = value
+		}
+		for _, name := range slices.Sorted(maps.Keys(attributes)) {
+			if attributes[name].IsRequired() && values[name].IsNull() {
+				diags.AddAttributeError(csvPath, "Invalid Meetings CSV", fmt.Sprintf("Line %d has no %s.", line, name))
+			}
+		}
+
+		object, d := types.ObjectValue(itemType.AttrTypes, values)
+		diags.Append(d...)
+		elements[key] = object
+	}
+	if diags.HasError() {
+		return types.MapNull(itemType)
+	}
+
+	meetings, d := types.MapValue(itemType, elements)
+	diags.Append(d...)
+	return meetings
+}
+
+// meetingsCsvDefault returns the value of a meeting attribute without a CSV cell
+func meetingsCsvDefault(ctx context.Context, attribute schema.Attribute) attr.Value {
+	switch attribute := attribute.(type) {
+	case schema.BoolAttribute:
+		if attribute.Default != nil {
+			var resp defaults.BoolResponse
+			attribute.Default.DefaultBool(ctx, defaults.BoolRequest{}, &resp)
+			return resp.PlanValue
+		}
+		return types.BoolNull()
+	case schema.SetAttribute:
+		if attribute.Default != nil {
+			var resp defaults.SetResponse
+			attribute.Default.DefaultSet(ctx, defaults.SetRequest{}, &resp)
+			return resp.PlanValue
+		}
+		return types.SetNull(attribute.ElementType)
+	case schema.Int64Attribute:
+		return types.Int64Null()
+	case schema.Float64Attribute:
+		return types.Float64Null()
+	default:
+		return types.StringNull()
+	}
+}
+
+// validateMeetingsCsvValue runs the validators of a meeting attribute on the
+// value of a CSV cell, which Terraform does not validate as configuration
+func validateMeetingsCsvValue(ctx context.Context, attribute schema.Attribute, value attr.Value, attributePath path.Path, diags *diag.Diagnostics) {
+	switch attribute := attribute.(type) {
+	case schema.StringAttribute:
+		req := validator.StringRequest{Path: attributePath, PathExpression: attributePath.Expression(), ConfigValue: value.(types.String)}
+		for _, v := range attribute.Validators {
+			var resp validator.StringResponse
+			v.ValidateString(ctx, req, &resp)
+			diags.Append(resp.Diagnostics...)
+		}
+	case schema.Int64Attribute:
+		req := validator.Int64Request{Path: attributePath, PathExpression: attributePath.Expression(), ConfigValue: value.(types.Int64)}
+		for _, v := range attribute.Validators {
+			var resp validator.Int64Response
+			v.ValidateInt64(ctx, req, &resp)
+			diags.Append(resp.Diagnostics...)
+		}
+	case schema.Float64Attribute:
+		req := validator.Float64Request{Path: attributePath, PathExpression: attributePath.Expression(), ConfigValue: value.(types.Float64)}
+		for _, v := range attribute.Validators {
+			var resp validator.Float64Response
+			v.ValidateFloat64(ctx, req, &resp)
+			diags.Append(resp.Diagnostics...)
+		}
+	}
+}
+
+// meetingsCsvValue converts a CSV cell to the value of a meeting attribute
+func meetingsCsvValue(attribute schema.Attribute, cell string) (attr.Value, error) {
+	switch attribute.(type) {
+	case schema.BoolAttribute:
+		v, err := strconv.ParseBool(strings.TrimSpace(cell))
+		if err != nil {
+			return nil, fmt.Errorf("expected true or false, got %q", cell)
+		}
+		return types.BoolValue(v), nil
+	case schema.Int64Attribute:
+		v, err := strconv.ParseInt(strings.TrimSpace(cell), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a whole number, got %q", cell)
+		}
+		return types.Int64Value(v), nil
+	case schema.Float64Attribute:
+		v, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", cell)
+		}
+		return types.Float64Value(v), nil
+	case schema.SetAttribute:
+		var elements []attr.Value
+		for _, key := range strings.FieldsFunc(cell, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+			elements = append(elements, types.StringValue(key))
+		}
+		return types.SetValueMust(types.StringType, elements), nil
+	default:
+		return types.StringValue(cell), nil
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bmlt-enabled/bmlt-server-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -33,44 +35,45 @@ type MeetingsDataSourceModel struct {
 }
 
 type MeetingModel struct {
-	Id                           types.Int64   `tfsdk:"id"`
-	ServiceBodyId                types.Int64   `tfsdk:"service_body_id"`
-	FormatIds                    []types.Int64 `tfsdk:"format_ids"`
-	VenueType                    types.Int64   `tfsdk:"venue_type"`
-	TemporarilyVirtual           types.Bool    `tfsdk:"temporarily_virtual"`
-	Day                          types.Int64   `tfsdk:"day"`
-	StartTime                    types.String  `tfsdk:"start_time"`
-	Duration                     types.String  `tfsdk:"duration"`
-	TimeZone                     types.String  `tfsdk:"time_zone"`
-	Latitude                     types.Float64 `tfsdk:"latitude"`
-	Longitude                    types.Float64 `tfsdk:"longitude"`
-	Published                    types.Bool    `tfsdk:"published"`
-	Email                        types.String  `tfsdk:"email"`
-	WorldId                      types.String  `tfsdk:"world_id"`
-	Name                         types.String  `tfsdk:"name"`
-	LocationText                 types.String  `tfsdk:"location_text"`
-	LocationInfo                 types.String  `tfsdk:"location_info"`
-	LocationStreet               types.String  `tfsdk:"location_street"`
-	LocationNeighborhood         types.String  `tfsdk:"location_neighborhood"`
-	LocationCitySubsection       types.String  `tfsdk:"location_city_subsection"`
-	LocationMunicipality         types.String  `tfsdk:"location_municipality"`
-	LocationSubProvince          types.String  `tfsdk:"location_sub_province"`
-	LocationProvince             types.String  `tfsdk:"location_province"`
-	LocationPostalCode1          types.String  `tfsdk:"location_postal_code_1"`
-	LocationNation               types.String  `tfsdk:"location_nation"`
-	PhoneMeetingNumber           types.String  `tfsdk:"phone_meeting_number"`
-	VirtualMeetingLink           types.String  `tfsdk:"virtual_meeting_link"`
-	VirtualMeetingAdditionalInfo types.String  `tfsdk:"virtual_meeting_additional_info"`
-	ContactName1                 types.String  `tfsdk:"contact_name_1"`
-	ContactName2                 types.String  `tfsdk:"contact_name_2"`
-	ContactPhone1                types.String  `tfsdk:"contact_phone_1"`
-	ContactPhone2                types.String  `tfsdk:"contact_phone_2"`
-	ContactEmail1                types.String  `tfsdk:"contact_email_1"`
-	ContactEmail2                types.String  `tfsdk:"contact_email_2"`
-	BusLines                     types.String  `tfsdk:"bus_lines"`
-	TrainLines                   types.String  `tfsdk:"train_lines"`
-	Comments                     types.String  `tfsdk:"comments"`
-	AdminNotes                   types.String  `tfsdk:"admin_notes"`
+	Id                           types.Int64             `tfsdk:"id"`
+	ServiceBodyId                types.Int64             `tfsdk:"service_body_id"`
+	FormatIds                    []types.Int64           `tfsdk:"format_ids"`
+	VenueType                    types.Int64             `tfsdk:"venue_type"`
+	TemporarilyVirtual           types.Bool              `tfsdk:"temporarily_virtual"`
+	Day                          types.Int64             `tfsdk:"day"`
+	StartTime                    types.String            `tfsdk:"start_time"`
+	Duration                     types.String            `tfsdk:"duration"`
+	TimeZone                     types.String            `tfsdk:"time_zone"`
+	Latitude                     types.Float64           `tfsdk:"latitude"`
+	Longitude                    types.Float64           `tfsdk:"longitude"`
+	Published                    types.Bool              `tfsdk:"published"`
+	Email                        types.String            `tfsdk:"email"`
+	WorldId                      types.String            `tfsdk:"world_id"`
+	Name                         types.String            `tfsdk:"name"`
+	LocationText                 types.String            `tfsdk:"location_text"`
+	LocationInfo                 types.String            `tfsdk:"location_info"`
+	LocationStreet               types.String            `tfsdk:"location_street"`
+	LocationNeighborhood         types.String            `tfsdk:"location_neighborhood"`
+	LocationCitySubsection       types.String            `tfsdk:"location_city_subsection"`
+	LocationMunicipality         types.String            `tfsdk:"location_municipality"`
+	LocationSubProvince          types.String            `tfsdk:"location_sub_province"`
+	LocationProvince             types.String            `tfsdk:"location_province"`
+	LocationPostalCode1          types.String            `tfsdk:"location_postal_code_1"`
+	LocationNation               types.String            `tfsdk:"location_nation"`
+	PhoneMeetingNumber           types.String            `tfsdk:"phone_meeting_number"`
+	VirtualMeetingLink           types.String            `tfsdk:"virtual_meeting_link"`
+	VirtualMeetingAdditionalInfo types.String            `tfsdk:"virtual_meeting_additional_info"`
+	ContactName1                 types.String            `tfsdk:"contact_name_1"`
+	ContactName2                 types.String            `tfsdk:"contact_name_2"`
+	ContactPhone1                types.String            `tfsdk:"contact_phone_1"`
+	ContactPhone2                types.String            `tfsdk:"contact_phone_2"`
+	ContactEmail1                types.String            `tfsdk:"contact_email_1"`
+	ContactEmail2                types.String            `tfsdk:"contact_email_2"`
+	BusLines                     types.String            `tfsdk:"bus_lines"`
+	TrainLines                   types.String            `tfsdk:"train_lines"`
+	Comments                     types.String            `tfsdk:"comments"`
+	AdminNotes                   types.String            `tfsdk:"admin_notes"`
+	CustomFields                 map[string]types.String `tfsdk:"custom_fields"`
 }
 
 func (d *MeetingsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -115,8 +118,8 @@ func (d *MeetingsDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 							MarkdownDescription: "Service body identifier",
 							Computed:            true,
 						},
-						"format_ids": schema.ListAttribute{
-							MarkdownDescription: "List of format identifiers",
+						"format_ids": schema.SetAttribute{
+							MarkdownDescription: "Set of format identifiers",
 							Computed:            true,
 							ElementType:         types.Int64Type,
 						},
@@ -260,6 +263,11 @@ func (d *MeetingsDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 							MarkdownDescription: "Admin notes (not visible to end users)",
 							Computed:            true,
 						},
+						"custom_fields": schema.MapAttribute{
+							MarkdownDescription: "Server-specific custom meeting fields, keyed by field name",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
 					},
 				},
 			},
@@ -292,35 +300,17 @@ func (d *MeetingsDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	// Build the API request with optional parameters
-	apiReq := d.client.Client.RootServerAPI.GetMeetings(d.client.Context)
-
-	if !data.MeetingIds.IsNull() && data.MeetingIds.ValueString() != "" {
-		apiReq = apiReq.MeetingIds(data.MeetingIds.ValueString())
-	}
-	if !data.Days.IsNull() && data.Days.ValueString() != "" {
-		apiReq = apiReq.Days(data.Days.ValueString())
-	}
-	if !data.ServiceBodyIds.IsNull() && data.ServiceBodyIds.ValueString() != "" {
-		apiReq = apiReq.ServiceBodyIds(data.ServiceBodyIds.ValueString())
-	}
-	if !data.SearchString.IsNull() && data.SearchString.ValueString() != "" {
-		apiReq = apiReq.SearchString(data.SearchString.ValueString())
-	}
-
-	// Execute the request
-	meetings, httpResp, err := apiReq.Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read meetings, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	// Search meetings using the configured filters
+	meetings, ok := getMeetings(ctx, d.client, meetingsFilter{
+		MeetingIds:     data.MeetingIds.ValueString(),
+		Days:           data.Days.ValueString(),
+		ServiceBodyIds: data.ServiceBodyIds.ValueString(),
+		SearchString:   data.SearchString.ValueString(),
+	}, &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
-	// Map response body to model
 	for _, meeting := range meetings {
 		meetingModel := MeetingModel{
 			Id:                           types.Int64Value(int64(meeting.Id)),
@@ -360,6 +350,7 @@ func (d *MeetingsDataSource) Read(ctx context.Context, req datasource.ReadReques
 			TrainLines:                   types.StringPointerValue(meeting.TrainLines),
 			Comments:                     types.StringPointerValue(meeting.Comments),
 			AdminNotes:                   types.StringPointerValue(meeting.AdminNotes),
+			CustomFields:                 customFieldsToModel(meeting.CustomFields),
 		}
 
 		// Handle format IDs
@@ -396,3 +387,38 @@ func (d *MeetingsDataSource) Read(ctx context.Context, req datasource.ReadReques
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// getMeetings searches meetings with the admin API, or the public semantic API
+// when the provider was configured without credentials
+func getMeetings(ctx context.Context, client *BMTLClientData, filter meetingsFilter, diags *diag.Diagnostics) ([]bmlt.Meeting, bool) {
+	if !client.Authenticated() {
+		meetings, err := getPublicMeetings(ctx, client, filter)
+		if err != nil {
+			diags.AddError("Client Error", "Unable to read meetings, got error: "+err.Error())
+			return nil, false
+		}
+		return meetings, true
+	}
+
+	apiReq := client.Client.RootServerAPI.GetMeetings(client.Context)
+
+	if filter.MeetingIds != "" {
+		apiReq = apiReq.MeetingIds(filter.MeetingIds)
+	}
+	if filter.Days != "" {
+		apiReq = apiReq.Days(filter.Days)
+	}
+	if filter.ServiceBodyIds != "" {
+		apiReq = apiReq.ServiceBodyIds(filter.ServiceBodyIds)
+	}
+	if filter.SearchString != "" {
+		apiReq = apiReq.SearchString(filter.SearchString)
+	}
+
+	meetings, httpResp, err := apiReq.Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(diags, "read meetings", httpResp, err, nil)
+		return nil, false
+	}
+	return meetings, true
+}
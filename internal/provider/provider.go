@@ -2,21 +2,34 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
-	"strings"
+	"strconv"
+	"time"
 
 	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"golang.org/x/oauth2"
 )
 
 // Ensure BMTProvider satisfies various provider interfaces.
 var _ provider.Provider = &BMTProvider{}
+var _ provider.ProviderWithEphemeralResources = &BMTProvider{}
+var _ provider.ProviderWithListResources = &BMTProvider{}
 
 // BMTProvider defines the provider implementation.
 type BMTProvider struct {
@@ -32,6 +45,25 @@ type BMTProviderModel struct {
 	Username    types.String `tfsdk:"username"`
 	Password    types.String `tfsdk:"password"`
 	AccessToken types.String `tfsdk:"access_token"`
+	// AccessTokenFile, PasswordFile and CredentialHelper are alternative credential sources
+	AccessTokenFile  types.String `tfsdk:"access_token_file"`
+	PasswordFile     types.String `tfsdk:"password_file"`
+	CredentialHelper types.String `tfsdk:"credential_helper"`
+	MaxRetries       types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin     types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax     types.String `tfsdk:"retry_wait_max"`
+
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+
+	VerifyOnConfigure types.Bool `tfsdk:"verify_on_configure"`
 }
 
 func (p *BMTProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -43,11 +75,11 @@ func (p *BMTProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				MarkdownDescription: "BMLT server host URL (e.g., https://example.com/main_server)",
+				MarkdownDescription: "BMLT server host URL (e.g., https://example.com/main_server). The scheme defaults to https, and a trailing /api/v1 is ignored.",
 				Optional:            true,
 			},
 			"username": schema.StringAttribute{
-				MarkdownDescription: "Username for BMLT server authentication",
+				MarkdownDescription: "Username for BMLT server authentication. Without username/password or access_token the provider runs in anonymous, read-only mode where only the bmlt_formats, bmlt_meetings, bmlt_service_bodies and bmlt_service_body data sources are available.",
 				Optional:            true,
 				Sensitive:           true,
 			},
@@ -61,6 +93,82 @@ func (p *BMTProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"access_token_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the OAuth2 access token (alternative to access_token). Can also be set with the BMLT_ACCESS_TOKEN_FILE environment variable.",
+				Optional:            true,
+			},
+			"password_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the password (alternative to password). Can also be set with the BMLT_PASSWORD_FILE environment variable.",
+				Optional:            true,
+			},
+			"credential_helper": schema.StringAttribute{
+				MarkdownDescription: "Command run through the system shell that writes credentials to stdout as a JSON object with either `username` and `password`, or `access_token`. The configured host is passed in the BMLT_HOST environment variable. Cannot be combined with any other credentials. Can also be set with the BMLT_CREDENTIAL_HELPER environment variable.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a failed API request is retried. Idempotent requests are retried on network errors and 5xx responses, and all requests are retried on 429 responses. Set to 0 to disable retries. Defaults to 3, or the BMLT_MAX_RETRIES environment variable.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "Minimum time to wait between retries, doubled on each attempt (e.g., 1s). A Retry-After header from the server takes precedence. Defaults to 1s, or the BMLT_RETRY_WAIT_MIN environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait between retries (e.g., 30s), also capping Retry-After headers. Defaults to 30s, or the BMLT_RETRY_WAIT_MAX environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of API requests per second across all resources and data sources. Useful for small BMLT installs on shared hosting. Defaults to unlimited (0), or the BMLT_REQUESTS_PER_SECOND environment variable.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at once, regardless of Terraform's parallelism. Defaults to unlimited (0), or the BMLT_MAX_CONCURRENT_REQUESTS environment variable.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded certificate authority certificates to trust in addition to the system pool, e.g. for a server using a private CA. Can also be set with the BMLT_CA_CERT_PEM environment variable.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file of PEM encoded certificate authority certificates to trust in addition to the system pool. Can also be set with the BMLT_CA_CERT_FILE environment variable.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS authentication. Requires client_key. Can also be set with the BMLT_CLIENT_CERT environment variable.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key for client_cert. Can also be set with the BMLT_CLIENT_KEY environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip TLS certificate verification. Only use this for testing against staging servers. Can also be set with the BMLT_INSECURE_SKIP_VERIFY environment variable.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP(S) proxy to route API requests through (e.g., http://proxy.example.com:3128). Can also be set with the BMLT_PROXY_URL environment variable. Defaults to the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.",
+				Optional:            true,
+			},
+			"verify_on_configure": schema.BoolAttribute{
+				MarkdownDescription: "Confirm that the host is a reachable BMLT root server of a supported version (" + minimumServerVersion + " or later) when the provider is configured. Can also be set with the BMLT_VERIFY_ON_CONFIGURE environment variable. Defaults to false.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -108,6 +216,35 @@ func (p *BMTProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		)
 	}
 
+	for _, attribute := range []struct {
+		name    string
+		unknown bool
+	}{
+		{"access_token_file", data.AccessTokenFile.IsUnknown()},
+		{"password_file", data.PasswordFile.IsUnknown()},
+		{"credential_helper", data.CredentialHelper.IsUnknown()},
+		{"max_retries", data.MaxRetries.IsUnknown()},
+		{"retry_wait_min", data.RetryWaitMin.IsUnknown()},
+		{"retry_wait_max", data.RetryWaitMax.IsUnknown()},
+		{"requests_per_second", data.RequestsPerSecond.IsUnknown()},
+		{"max_concurrent_requests", data.MaxConcurrentRequests.IsUnknown()},
+		{"ca_cert_pem", data.CACertPEM.IsUnknown()},
+		{"ca_cert_file", data.CACertFile.IsUnknown()},
+		{"client_cert", data.ClientCert.IsUnknown()},
+		{"client_key", data.ClientKey.IsUnknown()},
+		{"insecure_skip_verify", data.InsecureSkipVerify.IsUnknown()},
+		{"proxy_url", data.ProxyURL.IsUnknown()},
+		{"verify_on_configure", data.VerifyOnConfigure.IsUnknown()},
+	} {
+		if attribute.unknown {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Unknown BMLT Provider Configuration Value",
+				fmt.Sprintf("The provider cannot create the BMLT API client as there is an unknown configuration value for %s.", attribute.name),
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -144,20 +281,86 @@ func (p *BMTProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	// Validate authentication method - either username/password OR access_token
-	hasUsernamePassword := username != "" && password != ""
-	hasAccessToken := accessToken != ""
+	// Resolve credentials from files or a credential helper. Each is an
+	// alternative to the matching attribute, never an addition to it.
+	passwordFile := stringSetting(data.PasswordFile, "BMLT_PASSWORD_FILE")
+	accessTokenFile := stringSetting(data.AccessTokenFile, "BMLT_ACCESS_TOKEN_FILE")
+	credentialHelper := stringSetting(data.CredentialHelper, "BMLT_CREDENTIAL_HELPER")
 
-	if !hasUsernamePassword && !hasAccessToken {
-		resp.Diagnostics.AddError(
-			"Missing Authentication Configuration",
-			"The provider requires authentication. Provide either:\n"+
-				"1. Both username and password (via configuration or BMLT_USERNAME/BMLT_PASSWORD environment variables)\n"+
-				"2. An access_token (via configuration or BMLT_ACCESS_TOKEN environment variable)",
+	if password != "" && passwordFile != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_file"),
+			"Conflicting Authentication Configuration",
+			"The provider cannot use both password and password_file. Please provide only one of them.",
+		)
+	}
+
+	if accessToken != "" && accessTokenFile != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("access_token_file"),
+			"Conflicting Authentication Configuration",
+			"The provider cannot use both access_token and access_token_file. Please provide only one of them.",
 		)
+	}
+
+	if credentialHelper != "" && (username != "" || password != "" || passwordFile != "" || accessToken != "" || accessTokenFile != "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_helper"),
+			"Conflicting Authentication Configuration",
+			"The provider cannot use credential_helper together with username, password, password_file, access_token or access_token_file. "+
+				"Please provide credentials from only one source.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	var err error
+	if passwordFile != "" {
+		password, err = readSecretFile(passwordFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password_file"),
+				"Unable to Read Password File",
+				"The provider could not read the password file: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if accessTokenFile != "" {
+		accessToken, err = readSecretFile(accessTokenFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("access_token_file"),
+				"Unable to Read Access Token File",
+				"The provider could not read the access token file: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if credentialHelper != "" {
+		credentials, err := runCredentialHelper(ctx, credentialHelper, host)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credential_helper"),
+				"Credential Helper Failed",
+				"The provider could not obtain credentials from the credential helper: "+err.Error(),
+			)
+			return
+		}
+		username = credentials.Username
+		password = credentials.Password
+		accessToken = credentials.AccessToken
+	}
+
+	// Validate authentication method - either username/password OR access_token.
+	// Without credentials the provider runs in anonymous, read-only mode.
+	hasUsernamePassword := username != "" || password != ""
+	hasAccessToken := accessToken != ""
+
 	if hasUsernamePassword && hasAccessToken {
 		resp.Diagnostics.AddError(
 			"Conflicting Authentication Configuration",
@@ -185,125 +388,312 @@ func (p *BMTProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	// Parse the host URL to extract scheme, host, and path separately
-	var scheme, hostOnly, basePath string
-	if len(host) >= 7 && host[:7] == "http://" {
-		scheme = "http"
-		remaining := host[7:]
-		if idx := strings.Index(remaining, "/"); idx != -1 {
-			hostOnly = remaining[:idx]
-			basePath = remaining[idx:]
-		} else {
-			hostOnly = remaining
-			basePath = ""
-		}
-	} else if len(host) >= 8 && host[:8] == "https://" {
-		scheme = "https"
-		remaining := host[8:]
-		if idx := strings.Index(remaining, "/"); idx != -1 {
-			hostOnly = remaining[:idx]
-			basePath = remaining[idx:]
-		} else {
-			hostOnly = remaining
-			basePath = ""
-		}
-	} else {
-		// Default to https if no scheme provided
-		scheme = "https"
-		if idx := strings.Index(host, "/"); idx != -1 {
-			hostOnly = host[:idx]
-			basePath = host[idx:]
-		} else {
-			hostOnly = host
-			basePath = ""
-		}
+	// Resolve retry settings from config, environment variables or defaults
+	maxRetries := parseIntSetting(data.MaxRetries, "max_retries", "BMLT_MAX_RETRIES", defaultMaxRetries, &resp.Diagnostics)
+	retryWaitMin := parseDurationSetting(data.RetryWaitMin, "retry_wait_min", "BMLT_RETRY_WAIT_MIN", defaultRetryWaitMin, &resp.Diagnostics)
+	retryWaitMax := parseDurationSetting(data.RetryWaitMax, "retry_wait_max", "BMLT_RETRY_WAIT_MAX", defaultRetryWaitMax, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve request throttling settings
+	maxConcurrentRequests := parseIntSetting(data.MaxConcurrentRequests, "max_concurrent_requests", "BMLT_MAX_CONCURRENT_REQUESTS", 0, &resp.Diagnostics)
+	requestsPerSecond := parseFloatSetting(data.RequestsPerSecond, "requests_per_second", "BMLT_REQUESTS_PER_SECOND", 0, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Normalize basePath
-	if basePath != "" {
-		if !strings.HasPrefix(basePath, "/") {
-			basePath = "/" + basePath
+	// Resolve TLS and proxy settings
+	tlsOpts := tlsOptions{
+		CACertPEM:  stringSetting(data.CACertPEM, "BMLT_CA_CERT_PEM"),
+		CACertFile: stringSetting(data.CACertFile, "BMLT_CA_CERT_FILE"),
+		ClientCert: stringSetting(data.ClientCert, "BMLT_CLIENT_CERT"),
+		ClientKey:  stringSetting(data.ClientKey, "BMLT_CLIENT_KEY"),
+		ProxyURL:   stringSetting(data.ProxyURL, "BMLT_PROXY_URL"),
+	}
+	if v := os.Getenv("BMLT_INSECURE_SKIP_VERIFY"); v != "" {
+		skip, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid Boolean",
+				fmt.Sprintf("The BMLT_INSECURE_SKIP_VERIFY environment variable must be true or false, got: %q", v),
+			)
+			return
 		}
-		basePath = strings.TrimSuffix(basePath, "/")
+		tlsOpts.InsecureSkipVerify = skip
+	}
+	if !data.InsecureSkipVerify.IsNull() {
+		tlsOpts.InsecureSkipVerify = data.InsecureSkipVerify.ValueBool()
+	}
+
+	baseTransport, err := newBaseTransport(tlsOpts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid TLS or Proxy Configuration",
+			"The provider cannot create the BMLT API client: "+err.Error(),
+		)
+		return
+	}
+
+	if retryWaitMin > retryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid Retry Configuration",
+			fmt.Sprintf("retry_wait_min (%s) must not be greater than retry_wait_max (%s).", retryWaitMin, retryWaitMax),
+		)
+		return
+	}
+
+	// Parse and normalize the host URL
+	baseURL, err := parseHostURL(host)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Invalid BMLT API Host",
+			"The provider cannot create the BMLT API client as the host is malformed: "+err.Error()+". "+
+				"Expected the URL of the BMLT root server, e.g. https://example.com/main_server.",
+		)
+		return
 	}
 
 	// Create BMLT client configuration
 	cfg := bmlt.NewConfiguration()
-	cfg.Scheme = scheme
-	cfg.Host = hostOnly
-
-	// Add base path to servers configuration
-	if basePath != "" {
-		cfg.Servers = bmlt.ServerConfigurations{
-			{
-				URL:         scheme + "://" + hostOnly + basePath,
-				Description: "BMLT server with custom path",
-			},
-		}
+	cfg.Scheme = baseURL.Scheme
+	cfg.Host = baseURL.Host
+	cfg.Servers = bmlt.ServerConfigurations{
+		{
+			URL:         baseURL.String(),
+			Description: "BMLT root server",
+		},
 	}
 
-	client := bmlt.NewAPIClient(cfg)
+	// Requests are throttled per attempt, so retries also respect the limits
+	limiter := newRequestLimiter(requestsPerSecond, maxConcurrentRequests)
+	reauth := &reauthTransport{
+		next: newRetryTransport(
+			&limitTransport{next: newLoggingTransport(baseTransport), limiter: limiter},
+			maxRetries, retryWaitMin, retryWaitMax,
+		),
+	}
+	cfg.HTTPClient = &http.Client{Transport: reauth}
 
-	// Set up authentication based on the provided method
-	var authCtx context.Context
+	client := bmlt.NewAPIClient(cfg)
 
-	if hasAccessToken {
-		// Use provided access token directly
-		token := &oauth2.Token{
-			AccessToken: accessToken,
-			TokenType:   "bearer",
-		}
-		tokenSource := oauth2.StaticTokenSource(token)
-		authCtx = context.WithValue(context.Background(), bmlt.ContextOAuth2, tokenSource)
-	} else {
-		// Use username/password to obtain token
-		oauthConfig := &oauth2.Config{
-			Endpoint: oauth2.Endpoint{
-				TokenURL: scheme + "://" + hostOnly + basePath + "/api/v1/auth/token",
-			},
+	// Optionally confirm the host is a supported BMLT root server before authenticating
+	verifyOnConfigure := false
+	if v := os.Getenv("BMLT_VERIFY_ON_CONFIGURE"); v != "" {
+		verifyOnConfigure, err = strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("verify_on_configure"),
+				"Invalid Boolean",
+				fmt.Sprintf("The BMLT_VERIFY_ON_CONFIGURE environment variable must be true or false, got: %q", v),
+			)
+			return
 		}
+	}
+	if !data.VerifyOnConfigure.IsNull() {
+		verifyOnConfigure = data.VerifyOnConfigure.ValueBool()
+	}
 
-		// Use background context for OAuth2 authentication to avoid timeouts
-		token, err := oauthConfig.PasswordCredentialsToken(context.Background(), username, password)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Create BMLT API Client",
-				"An unexpected error occurred when creating the BMLT API client using username/password: "+err.Error(),
+	if verifyOnConfigure {
+		if _, err := verifyServer(ctx, cfg.HTTPClient, baseURL); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Unable to Verify BMLT Root Server",
+				"The provider could not confirm that the host is a supported BMLT root server: "+err.Error(),
 			)
 			return
 		}
+	}
+
+	// API calls outlive this Configure call, so their context must not be
+	// cancelled with it. It keeps the provider logger so requests are logged via tflog.
+	baseCtx := context.WithoutCancel(ctx)
+
+	// Token requests use the same HTTP client so they honor the TLS and proxy settings
+	oauthCtx := context.WithValue(baseCtx, oauth2.HTTPClient, cfg.HTTPClient)
+
+	// Set up authentication based on the provided method. Tokens are only
+	// requested once an API call needs one.
+	var tokenSource *bmltTokenSource
+	authCtx := baseCtx
+	switch {
+	case hasAccessToken:
+		tokenSource = newAccessTokenSource(oauthCtx, client, accessToken)
+	case hasUsernamePassword:
+		tokenSource = newPasswordTokenSource(oauthCtx, client, baseURL.String(), username, password)
+	default:
+		tflog.Info(ctx, "No BMLT credentials configured, only public data sources are available")
+	}
 
-		// Create authenticated context using background context
-		tokenSource := oauthConfig.TokenSource(context.Background(), token)
-		authCtx = context.WithValue(context.Background(), bmlt.ContextOAuth2, tokenSource)
+	if tokenSource != nil {
+		// Requests rejected with 401 are retried once with a renewed token
+		reauth.tokens = tokenSource
+		authCtx = context.WithValue(baseCtx, bmlt.ContextOAuth2, oauth2.TokenSource(tokenSource))
 	}
 
 	// Create a client data structure to pass to resources and data sources
 	clientData := &BMTLClientData{
-		Client:  client,
-		Context: authCtx,
+		Client:           client,
+		Context:          authCtx,
+		TokenSource:      tokenSource,
+		HTTPClient:       cfg.HTTPClient,
+		BaseURL:          baseURL,
+		ServiceBodyLocks: &keyedMutex{},
 	}
 
 	resp.DataSourceData = clientData
 	resp.ResourceData = clientData
+	resp.EphemeralResourceData = clientData
+	resp.ListResourceData = clientData
+}
+
+// parseDurationSetting resolves a duration provider attribute, falling back to
+// an environment variable and then to a default value
+func parseDurationSetting(value types.String, attribute, envVar string, defaultValue time.Duration, diags *diag.Diagnostics) time.Duration {
+	raw := os.Getenv(envVar)
+	if !value.IsNull() {
+		raw = value.ValueString()
+	}
+	if raw == "" {
+		return defaultValue
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Duration",
+			fmt.Sprintf("%s (or the %s environment variable) must be a non-negative duration such as 500ms, 30s or 1m30s, got: %q", attribute, envVar, raw),
+		)
+		return defaultValue
+	}
+	return d
+}
+
+// stringSetting resolves a string provider attribute, falling back to an environment variable
+func stringSetting(value types.String, envVar string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	return os.Getenv(envVar)
+}
+
+// parseIntSetting resolves a non-negative integer provider attribute, falling
+// back to an environment variable and then to a default value
+func parseIntSetting(value types.Int64, attribute, envVar string, defaultValue int, diags *diag.Diagnostics) int {
+	if !value.IsNull() {
+		return int(value.ValueInt64())
+	}
+
+	raw := os.Getenv(envVar)
+	if raw == "" {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Integer",
+			fmt.Sprintf("The %s environment variable must be a non-negative integer, got: %q", envVar, raw),
+		)
+		return defaultValue
+	}
+	return n
+}
+
+// parseFloatSetting resolves a non-negative number setting from config, an
+// environment variable or a default, in that order of precedence
+func parseFloatSetting(value types.Float64, attribute, envVar string, defaultValue float64, diags *diag.Diagnostics) float64 {
+	if !value.IsNull() {
+		return value.ValueFloat64()
+	}
+
+	raw := os.Getenv(envVar)
+	if raw == "" {
+		return defaultValue
+	}
+
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || f < 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Number",
+			fmt.Sprintf("The %s environment variable must be a non-negative number, got: %q", envVar, raw),
+		)
+		return defaultValue
+	}
+	return f
 }
 
 // BMTLClientData contains the authenticated client and context for use by resources and data sources
 type BMTLClientData struct {
 	Client  *bmlt.APIClient
 	Context context.Context
+	// TokenSource supplies the access token used by Context and renews it when
+	// it expires. It is nil when the provider was configured without credentials.
+	TokenSource *bmltTokenSource
+	// HTTPClient and BaseURL are used for the public semantic API
+	HTTPClient *http.Client
+	BaseURL    *url.URL
+	// ServiceBodyLocks serializes updates of a service body's user assignments
+	ServiceBodyLocks *keyedMutex
+
+	formatKeysCache formatKeysCache
+}
+
+// Authenticated reports whether the provider was configured with credentials
+func (c *BMTLClientData) Authenticated() bool {
+	return c.TokenSource != nil
+}
+
+// requireAuthentication adds an error diagnostic when the provider was
+// configured without credentials, returning false in that case
+func requireAuthentication(client *BMTLClientData, diags *diag.Diagnostics) bool {
+	if client.Authenticated() {
+		return true
+	}
+
+	diags.AddError(
+		"Missing Authentication Configuration",
+		"This operation requires the provider to be configured with credentials. Provide either:\n"+
+			"1. Both username and password (via configuration or BMLT_USERNAME/BMLT_PASSWORD environment variables)\n"+
+			"2. An access_token (via configuration or BMLT_ACCESS_TOKEN environment variable)\n\n"+
+			"Without credentials only the bmlt_formats, bmlt_meetings, bmlt_service_bodies and bmlt_service_body data sources are available.",
+	)
+	return false
 }
 
 func (p *BMTProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewFormatResource,
 		NewMeetingResource,
+		NewMeetingScheduleResource,
 		NewServiceBodyResource,
+		NewServiceBodyUserAssignmentResource,
 		NewSettingsResource,
 		NewUserResource,
 	}
 }
 
+func (p *BMTProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAccessTokenEphemeralResource,
+	}
+}
+
+func (p *BMTProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewFormatListResource,
+		NewMeetingListResource,
+		NewServiceBodyListResource,
+		NewUserListResource,
+	}
+}
+
 func (p *BMTProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewFormatsDataSource,
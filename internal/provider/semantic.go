@@ -0,0 +1,346 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bmlt-enabled/bmlt-server-go-client"
+)
+
+// semanticAPIPath is the path of the public, unauthenticated BMLT semantic API
+const semanticAPIPath = "/client_interface/json/"
+
+// The semantic API returns all values as strings. These types describe the
+// subset of its responses needed to build the equivalent admin API models.
+
+type semanticFormat struct {
+	Id          string `json:"id"`
+	WorldId     string `json:"world_id"`
+	Type        string `json:"format_type_enum"`
+	Key         string `json:"key_string"`
+	Name        string `json:"name_string"`
+	Description string `json:"description_string"`
+	Language    string `json:"lang"`
+}
+
+type semanticServiceBody struct {
+	Id          string `json:"id"`
+	ParentId    string `json:"parent_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Url         string `json:"url"`
+	Helpline    string `json:"helpline"`
+	WorldId     string `json:"world_id"`
+}
+
+type semanticMeeting struct {
+	Id                           string `json:"id_bigint"`
+	WorldId                      string `json:"worldid_mixed"`
+	ServiceBodyId                string `json:"service_body_bigint"`
+	Weekday                      string `json:"weekday_tinyint"`
+	VenueType                    string `json:"venue_type"`
+	StartTime                    string `json:"start_time"`
+	Duration                     string `json:"duration_time"`
+	TimeZone                     string `json:"time_zone"`
+	FormatIds                    string `json:"format_shared_id_list"`
+	Latitude                     string `json:"latitude"`
+	Longitude                    string `json:"longitude"`
+	Published                    string `json:"published"`
+	Name                         string `json:"meeting_name"`
+	LocationText                 string `json:"location_text"`
+	LocationInfo                 string `json:"location_info"`
+	LocationStreet               string `json:"location_street"`
+	LocationNeighborhood         string `json:"location_neighborhood"`
+	LocationCitySubsection       string `json:"location_city_subsection"`
+	LocationMunicipality         string `json:"location_municipality"`
+	LocationSubProvince          string `json:"location_sub_province"`
+	LocationProvince             string `json:"location_province"`
+	LocationPostalCode1          string `json:"location_postal_code_1"`
+	LocationNation               string `json:"location_nation"`
+	PhoneMeetingNumber           string `json:"phone_meeting_number"`
+	VirtualMeetingLink           string `json:"virtual_meeting_link"`
+	VirtualMeetingAdditionalInfo string `json:"virtual_meeting_additional_info"`
+	BusLines                     string `json:"bus_lines"`
+	TrainLines                   string `json:"train_lines"`
+	Comments                     string `json:"comments"`
+}
+
+// semanticServerInfo is the subset of the GetServerInfo response listing the
+// languages configured on the server
+type semanticServerInfo struct {
+	Languages string `json:"langs"`
+}
+
+// getSemantic calls a semantic API switcher and decodes the JSON response into v
+func getSemantic(ctx context.Context, client *BMTLClientData, switcher string, params url.Values, v interface{}) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("switcher", switcher)
+	requestURL := client.BaseURL.String() + semanticAPIPath + "?" + params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read the %s response: %w", switcher, err)
+	}
+
+	if resp.StatusCode != HTTPStatusOK {
+		return fmt.Errorf("%s returned %s", switcher, resp.Status)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("unable to decode the %s response: %w", switcher, err)
+	}
+	return nil
+}
+
+// getPublicFormats returns all formats from the semantic API. When language is
+// empty, translations for every language configured on the server are returned.
+func getPublicFormats(ctx context.Context, client *BMTLClientData, language string) ([]bmlt.Format, error) {
+	languages := []string{language}
+	if language == "" {
+		var infos []semanticServerInfo
+		if err := getSemantic(ctx, client, "GetServerInfo", nil, &infos); err != nil {
+			return nil, err
+		}
+		if len(infos) == 0 {
+			return nil, fmt.Errorf("GetServerInfo returned no server info")
+		}
+		languages = strings.Split(infos[0].Languages, ",")
+	}
+
+	params := url.Values{}
+	params.Set("show_all", "1")
+	for _, lang := range languages {
+		params.Add("lang_enum[]", strings.TrimSpace(lang))
+	}
+
+	var semanticFormats []semanticFormat
+	if err := getSemantic(ctx, client, "GetFormats", params, &semanticFormats); err != nil {
+		return nil, err
+	}
+
+	// The semantic API returns one entry per translation, group them by format
+	var formats []bmlt.Format
+	indexes := make(map[int32]int)
+	for _, sf := range semanticFormats {
+		id, err := semanticInt32(sf.Id)
+		if err != nil {
+			return nil, fmt.Errorf("GetFormats returned an invalid format id: %w", err)
+		}
+
+		i, ok := indexes[id]
+		if !ok {
+			i = len(formats)
+			indexes[id] = i
+			formats = append(formats, bmlt.Format{
+				Id:      id,
+				WorldId: sf.WorldId,
+				Type:    sf.Type,
+			})
+		}
+
+		formats[i].Translations = append(formats[i].Translations, bmlt.FormatTranslation{
+			Key:         sf.Key,
+			Name:        sf.Name,
+			Description: sf.Description,
+			Language:    sf.Language,
+		})
+	}
+
+	return formats, nil
+}
+
+// getPublicServiceBodies returns all service bodies from the semantic API.
+// Administrative fields such as users and email addresses are not public and
+// are left empty.
+func getPublicServiceBodies(ctx context.Context, client *BMTLClientData) ([]bmlt.ServiceBody, error) {
+	var semanticServiceBodies []semanticServiceBody
+	if err := getSemantic(ctx, client, "GetServiceBodies", nil, &semanticServiceBodies); err != nil {
+		return nil, err
+	}
+
+	serviceBodies := make([]bmlt.ServiceBody, 0, len(semanticServiceBodies))
+	for _, ssb := range semanticServiceBodies {
+		id, err := semanticInt32(ssb.Id)
+		if err != nil {
+			return nil, fmt.Errorf("GetServiceBodies returned an invalid service body id: %w", err)
+		}
+
+		serviceBody := bmlt.ServiceBody{
+			Id:          id,
+			Name:        ssb.Name,
+			Description: ssb.Description,
+			Type:        ssb.Type,
+			Url:         ssb.Url,
+			Helpline:    ssb.Helpline,
+			WorldId:     ssb.WorldId,
+		}
+
+		// Top level service bodies have a parent id of 0
+		if parentId, err := semanticInt32(ssb.ParentId); err == nil && parentId != 0 {
+			serviceBody.ParentId.Set(&parentId)
+		}
+
+		serviceBodies = append(serviceBodies, serviceBody)
+	}
+
+	return serviceBodies, nil
+}
+
+// meetingsFilter holds the admin API style meeting search filters, each a
+// comma delimited list except for the search string
+type meetingsFilter struct {
+	MeetingIds     string
+	Days           string
+	ServiceBodyIds string
+	SearchString   string
+}
+
+// getPublicMeetings searches published meetings with the semantic API.
+// Contact details, admin notes and custom fields are not public and are left empty.
+func getPublicMeetings(ctx context.Context, client *BMTLClientData, filter meetingsFilter) ([]bmlt.Meeting, error) {
+	params := url.Values{}
+	for _, id := range splitList(filter.MeetingIds) {
+		params.Add("meeting_ids[]", id)
+	}
+	for _, day := range splitList(filter.Days) {
+		// The semantic API numbers weekdays from 1 (Sunday) to 7
+		d, err := strconv.Atoi(day)
+		if err != nil {
+			return nil, fmt.Errorf("invalid day %q: %w", day, err)
+		}
+		params.Add("weekdays[]", strconv.Itoa(d+1))
+	}
+	for _, id := range splitList(filter.ServiceBodyIds) {
+		params.Add("services[]", id)
+	}
+	if filter.SearchString != "" {
+		params.Set("SearchString", filter.SearchString)
+	}
+
+	var semanticMeetings []semanticMeeting
+	if err := getSemantic(ctx, client, "GetSearchResults", params, &semanticMeetings); err != nil {
+		return nil, err
+	}
+
+	meetings := make([]bmlt.Meeting, 0, len(semanticMeetings))
+	for _, sm := range semanticMeetings {
+		meeting, err := sm.toMeeting()
+		if err != nil {
+			return nil, fmt.Errorf("GetSearchResults returned an invalid meeting: %w", err)
+		}
+		meetings = append(meetings, meeting)
+	}
+
+	return meetings, nil
+}
+
+// toMeeting converts a semantic API meeting to the admin API representation
+func (sm semanticMeeting) toMeeting() (bmlt.Meeting, error) {
+	id, err := semanticInt32(sm.Id)
+	if err != nil {
+		return bmlt.Meeting{}, err
+	}
+	serviceBodyId, _ := semanticInt32(sm.ServiceBodyId)
+	weekday, _ := semanticInt32(sm.Weekday)
+	venueType, _ := semanticInt32(sm.VenueType)
+	latitude, _ := strconv.ParseFloat(sm.Latitude, 32)
+	longitude, _ := strconv.ParseFloat(sm.Longitude, 32)
+
+	var formatIds []int32
+	for _, formatId := range splitList(sm.FormatIds) {
+		if id, err := semanticInt32(formatId); err == nil {
+			formatIds = append(formatIds, id)
+		}
+	}
+
+	return bmlt.Meeting{
+		Id:                           id,
+		ServiceBodyId:                serviceBodyId,
+		FormatIds:                    formatIds,
+		VenueType:                    venueType,
+		Day:                          weekday - 1,
+		StartTime:                    semanticTimeOfDay(sm.StartTime),
+		Duration:                     semanticTimeOfDay(sm.Duration),
+		TimeZone:                     sm.TimeZone,
+		Latitude:                     float32(latitude),
+		Longitude:                    float32(longitude),
+		Published:                    sm.Published != "0",
+		WorldId:                      sm.WorldId,
+		Name:                         sm.Name,
+		LocationText:                 semanticString(sm.LocationText),
+		LocationInfo:                 semanticString(sm.LocationInfo),
+		LocationStreet:               semanticString(sm.LocationStreet),
+		LocationNeighborhood:         semanticString(sm.LocationNeighborhood),
+		LocationCitySubsection:       semanticString(sm.LocationCitySubsection),
+		LocationMunicipality:         semanticString(sm.LocationMunicipality),
+		LocationSubProvince:          semanticString(sm.LocationSubProvince),
+		LocationProvince:             semanticString(sm.LocationProvince),
+		LocationPostalCode1:          semanticString(sm.LocationPostalCode1),
+		LocationNation:               semanticString(sm.LocationNation),
+		PhoneMeetingNumber:           semanticString(sm.PhoneMeetingNumber),
+		VirtualMeetingLink:           semanticString(sm.VirtualMeetingLink),
+		VirtualMeetingAdditionalInfo: semanticString(sm.VirtualMeetingAdditionalInfo),
+		BusLines:                     semanticString(sm.BusLines),
+		TrainLines:                   semanticString(sm.TrainLines),
+		Comments:                     semanticString(sm.Comments),
+	}, nil
+}
+
+// semanticInt32 parses a numeric semantic API value
+func semanticInt32(value string) (int32, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(n), nil
+}
+
+// semanticString converts an empty semantic API value to nil, matching the
+// admin API which omits unset meeting fields
+func semanticString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// semanticTimeOfDay trims the seconds from an HH:MM:SS semantic API time to
+// match the HH:MM format of the admin API
+func semanticTimeOfDay(value string) string {
+	if len(value) == len("15:04:05") {
+		return value[:len("15:04")]
+	}
+	return value
+}
+
+// splitList splits a comma delimited data source filter, ignoring empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
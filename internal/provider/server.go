@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// minimumServerVersion is the first BMLT root server release providing the
+// /api/v1 admin API used by this provider
+const minimumServerVersion = "3.0.0"
+
+// apiPathSuffix is the admin API prefix appended to the base URL by the client
+const apiPathSuffix = "/api/v1"
+
+// parseHostURL parses and normalizes the provider host into the base URL of a
+// BMLT root server, e.g. https://example.com:8443/main_server. A missing scheme
+// defaults to https, the host name is lowercased, and trailing slashes and a
+// trailing /api/v1 are removed.
+func parseHostURL(host string) (*url.URL, error) {
+	host = strings.TrimSpace(host)
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("the host is not a valid URL: %w", err)
+	}
+
+	// url.Parse lowercases the scheme, so HTTPS:// is accepted
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("the host URL scheme must be http or https, got: %q", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("the host URL %q does not contain a host name", host)
+	}
+
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return nil, fmt.Errorf("the host URL port must be between 1 and 65535, got: %q", port)
+		}
+	}
+
+	if u.User != nil {
+		return nil, fmt.Errorf("the host URL must not contain credentials, use username/password or access_token instead")
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" || u.ForceQuery {
+		return nil, fmt.Errorf("the host URL must not contain a query string or fragment, got: %q", host)
+	}
+
+	basePath := strings.TrimRight(u.Path, "/")
+	if strings.HasSuffix(strings.ToLower(basePath), apiPathSuffix) {
+		basePath = strings.TrimRight(basePath[:len(basePath)-len(apiPathSuffix)], "/")
+	}
+
+	return &url.URL{
+		Scheme: u.Scheme,
+		Host:   strings.ToLower(u.Host),
+		Path:   basePath,
+	}, nil
+}
+
+// serverInfo is the subset of the GetServerInfo semantic response used to
+// identify a BMLT root server
+type serverInfo struct {
+	Version string `json:"version"`
+}
+
+// verifyServer confirms that baseURL is a reachable BMLT root server running a
+// supported version, using the public GetServerInfo endpoint
+func verifyServer(ctx context.Context, client *http.Client, baseURL *url.URL) (string, error) {
+	infoURL := baseURL.String() + semanticAPIPath + "?switcher=GetServerInfo"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, infoURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to reach %s: %w", baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the server info response from %s: %w", baseURL, err)
+	}
+
+	if resp.StatusCode != HTTPStatusOK {
+		return "", fmt.Errorf("%s returned %s, check that the host points at the BMLT root server (e.g., https://example.com/main_server)", infoURL, resp.Status)
+	}
+
+	var infos []serverInfo
+	if err := json.Unmarshal(body, &infos); err != nil || len(infos) == 0 || infos[0].Version == "" {
+		return "", fmt.Errorf("%s did not return BMLT server info, check that the host points at the BMLT root server (e.g., https://example.com/main_server)", infoURL)
+	}
+
+	version := infos[0].Version
+	if compareVersions(version, minimumServerVersion) < 0 {
+		return version, fmt.Errorf("the BMLT root server at %s is running version %s, but this provider requires version %s or later", baseURL, version, minimumServerVersion)
+	}
+
+	return version, nil
+}
+
+// compareVersions compares two dotted version strings numerically, returning
+// -1, 0 or 1. Missing or non-numeric components are treated as 0.
+func compareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/bmlt-enabled/bmlt-server-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -80,8 +82,8 @@ func (d *ServiceBodiesDataSource) Schema(ctx context.Context, req datasource.Sch
 							MarkdownDescription: "Admin user identifier",
 							Computed:            true,
 						},
-						"assigned_user_ids": schema.ListAttribute{
-							MarkdownDescription: "List of assigned user identifiers",
+						"assigned_user_ids": schema.SetAttribute{
+							MarkdownDescription: "Set of assigned user identifiers",
 							Computed:            true,
 							ElementType:         types.Int64Type,
 						},
@@ -134,14 +136,8 @@ func (d *ServiceBodiesDataSource) Read(ctx context.Context, req datasource.ReadR
 	}
 
 	// Get service bodies from the API
-	serviceBodies, httpResp, err := d.client.Client.RootServerAPI.GetServiceBodies(d.client.Context).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service bodies, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	serviceBodies, ok := getServiceBodies(ctx, d.client, &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
@@ -179,3 +175,23 @@ func (d *ServiceBodiesDataSource) Read(ctx context.Context, req datasource.ReadR
 	data.Id = types.StringValue("placeholder")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// getServiceBodies returns service bodies from the admin API, or the public
+// semantic API when the provider was configured without credentials
+func getServiceBodies(ctx context.Context, client *BMTLClientData, diags *diag.Diagnostics) ([]bmlt.ServiceBody, bool) {
+	if !client.Authenticated() {
+		serviceBodies, err := getPublicServiceBodies(ctx, client)
+		if err != nil {
+			diags.AddError("Client Error", "Unable to read service bodies, got error: "+err.Error())
+			return nil, false
+		}
+		return serviceBodies, true
+	}
+
+	serviceBodies, httpResp, err := client.Client.RootServerAPI.GetServiceBodies(client.Context).Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(diags, "read service bodies", httpResp, err, nil)
+		return nil, false
+	}
+	return serviceBodies, true
+}
@@ -72,8 +72,8 @@ func (d *ServiceBodyDataSource) Schema(ctx context.Context, req datasource.Schem
 				MarkdownDescription: "Admin user identifier",
 				Computed:            true,
 			},
-			"assigned_user_ids": schema.ListAttribute{
-				MarkdownDescription: "List of assigned user identifiers",
+			"assigned_user_ids": schema.SetAttribute{
+				MarkdownDescription: "Set of assigned user identifiers",
 				Computed:            true,
 				ElementType:         types.Int64Type,
 			},
@@ -142,52 +142,47 @@ func (d *ServiceBodyDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	// If service_body_id is provided, fetch service body directly
-	if hasServiceBodyId {
+	// If service_body_id is provided, fetch service body directly. The public
+	// semantic API has no single service body lookup, so without credentials
+	// the service body is found in the full list instead.
+	if hasServiceBodyId && d.client.Authenticated() {
 		serviceBodyId := data.ServiceBodyId.ValueInt64()
 		serviceBody, httpResp, err := d.client.Client.RootServerAPI.GetServiceBody(d.client.Context, serviceBodyId).Execute()
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service body, got error: %s", err))
-			return
-		}
-
-		if httpResp.StatusCode == HTTPStatusNotFound {
+		if httpResp != nil && httpResp.StatusCode == HTTPStatusNotFound {
 			resp.Diagnostics.AddError("Service Body Not Found", fmt.Sprintf("Service body with ID %d not found", serviceBodyId))
 			return
 		}
 
-		if httpResp.StatusCode != HTTPStatusOK {
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+		if err != nil || httpResp.StatusCode != HTTPStatusOK {
+			addAPIError(&resp.Diagnostics, "read service body", httpResp, err, nil)
 			return
 		}
 
 		// Map response to model
 		d.mapServiceBodyToModel(&data, serviceBody)
 	} else {
-		// If name is provided, fetch all service bodies and filter
-		targetName := data.Name.ValueString()
-		serviceBodies, httpResp, err := d.client.Client.RootServerAPI.GetServiceBodies(d.client.Context).Execute()
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service bodies, got error: %s", err))
+		// Otherwise fetch all service bodies and filter
+		serviceBodies, ok := getServiceBodies(ctx, d.client, &resp.Diagnostics)
+		if !ok {
 			return
 		}
 
-		if httpResp.StatusCode != HTTPStatusOK {
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
-			return
-		}
-
-		// Find service body by name
+		// Find service body by id or name
 		var foundServiceBody *bmlt.ServiceBody
 		for _, serviceBody := range serviceBodies {
-			if serviceBody.Name == targetName {
+			if (hasServiceBodyId && int64(serviceBody.Id) == data.ServiceBodyId.ValueInt64()) ||
+				(hasName && serviceBody.Name == data.Name.ValueString()) {
 				foundServiceBody = &serviceBody
 				break
 			}
 		}
 
 		if foundServiceBody == nil {
-			resp.Diagnostics.AddError("Service Body Not Found", fmt.Sprintf("Service body with name '%s' not found", targetName))
+			if hasServiceBodyId {
+				resp.Diagnostics.AddError("Service Body Not Found", fmt.Sprintf("Service body with ID %d not found", data.ServiceBodyId.ValueInt64()))
+			} else {
+				resp.Diagnostics.AddError("Service Body Not Found", fmt.Sprintf("Service body with name '%s' not found", data.Name.ValueString()))
+			}
 			return
 		}
 
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &ServiceBodyResource{}
+var _ list.ListResourceWithConfigure = &ServiceBodyResource{}
+
+func NewServiceBodyListResource() list.ListResource {
+	return &ServiceBodyResource{}
+}
+
+type ServiceBodyListResourceModel struct {
+	ParentId types.Int64 `tfsdk:"parent_id"`
+}
+
+func (r *ServiceBodyResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists existing service bodies so they can be imported with terraform query",
+
+		Attributes: map[string]listschema.Attribute{
+			"parent_id": listschema.Int64Attribute{
+				MarkdownDescription: "Only list the direct children of this service body",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *ServiceBodyResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config ServiceBodyListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	serviceBodies, ok := getServiceBodies(ctx, r.client, &diags)
+	if !ok {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if !config.ParentId.IsNull() {
+		var children []bmlt.ServiceBody
+		for _, serviceBody := range serviceBodies {
+			if parentId := serviceBody.ParentId.Get(); parentId != nil && int64(*parentId) == config.ParentId.ValueInt64() {
+				children = append(children, serviceBody)
+			}
+		}
+		serviceBodies = children
+	}
+
+	stream.Results = streamListResults(req, serviceBodies, func(serviceBody bmlt.ServiceBody) list.ListResult {
+		result := req.NewListResult(ctx)
+		result.DisplayName = serviceBody.Name
+
+		data := &ServiceBodyResourceModel{Id: types.StringValue(strconv.Itoa(int(serviceBody.Id)))}
+		r.updateModelFromServiceBody(data, &serviceBody)
+
+		result.Diagnostics.Append(result.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
+		if req.IncludeResource {
+			result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+		}
+		return result
+	})
+}
@@ -4,18 +4,22 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/bmlt-enabled/bmlt-server-go-client"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = &ServiceBodyResource{}
 var _ resource.ResourceWithImportState = &ServiceBodyResource{}
+var _ resource.ResourceWithIdentity = &ServiceBodyResource{}
+var _ resource.ResourceWithUpgradeState = &ServiceBodyResource{}
 
 func NewServiceBodyResource() resource.Resource {
 	return &ServiceBodyResource{}
@@ -26,27 +30,32 @@ type ServiceBodyResource struct {
 }
 
 type ServiceBodyResourceModel struct {
-	Id              types.String  `tfsdk:"id"`
-	ParentId        types.Int64   `tfsdk:"parent_id"`
-	Name            types.String  `tfsdk:"name"`
-	Description     types.String  `tfsdk:"description"`
-	Type            types.String  `tfsdk:"type"`
-	AdminUserId     types.Int64   `tfsdk:"admin_user_id"`
-	AssignedUserIds []types.Int64 `tfsdk:"assigned_user_ids"`
-	Url             types.String  `tfsdk:"url"`
-	Helpline        types.String  `tfsdk:"helpline"`
-	Email           types.String  `tfsdk:"email"`
-	WorldId         types.String  `tfsdk:"world_id"`
-	ForceDelete     types.Bool    `tfsdk:"force_delete"`
+	Id              types.String `tfsdk:"id"`
+	ParentId        types.Int64  `tfsdk:"parent_id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	Type            types.String `tfsdk:"type"`
+	AdminUserId     types.Int64  `tfsdk:"admin_user_id"`
+	AssignedUserIds types.Set    `tfsdk:"assigned_user_ids"`
+	Url             types.String `tfsdk:"url"`
+	Helpline        types.String `tfsdk:"helpline"`
+	Email           types.String `tfsdk:"email"`
+	WorldId         types.String `tfsdk:"world_id"`
+	ForceDelete     types.Bool   `tfsdk:"force_delete"`
 }
 
 func (r *ServiceBodyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_service_body"
 }
 
+func (r *ServiceBodyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = objectIdentitySchema("Service body identifier")
+}
+
 func (r *ServiceBodyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Service body resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -76,10 +85,15 @@ func (r *ServiceBodyResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "Admin user identifier",
 				Required:            true,
 			},
-			"assigned_user_ids": schema.ListAttribute{
-				MarkdownDescription: "List of assigned user identifiers",
-				Required:            true,
-				ElementType:         types.Int64Type,
+			"assigned_user_ids": schema.SetAttribute{
+				MarkdownDescription: "Set of assigned user identifiers. Leave unset to keep the assignments on the server unchanged, " +
+					"e.g. when they are managed with bmlt_service_body_user_assignment.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Int64Type,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"url": schema.StringAttribute{
 				MarkdownDescription: "Service body URL",
@@ -119,6 +133,10 @@ func (r *ServiceBodyResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
+	if !requireAuthentication(client, &resp.Diagnostics) {
+		return
+	}
+
 	r.client = client
 }
 
@@ -130,10 +148,13 @@ func (r *ServiceBodyResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	// Convert assigned user IDs
-	var assignedUserIds []int32
-	for _, id := range data.AssignedUserIds {
-		assignedUserIds = append(assignedUserIds, safeInt64ToInt32(id.ValueInt64()))
+	// Convert assigned user IDs, new service bodies have no assignments unless configured
+	assignedUserIds := []int32{}
+	if !data.AssignedUserIds.IsUnknown() {
+		resp.Diagnostics.Append(data.AssignedUserIds.ElementsAs(ctx, &assignedUserIds, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	// Create NullableInt32 for ParentId
@@ -158,13 +179,8 @@ func (r *ServiceBodyResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Create service body
 	serviceBody, httpResp, err := r.client.Client.RootServerAPI.CreateServiceBody(r.client.Context).ServiceBodyCreate(createRequest).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create service body, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusCreated {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusCreated {
+		addAPIError(&resp.Diagnostics, "create service body", httpResp, err, data)
 		return
 	}
 
@@ -173,6 +189,7 @@ func (r *ServiceBodyResource) Create(ctx context.Context, req resource.CreateReq
 	r.updateModelFromServiceBody(data, serviceBody)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *ServiceBodyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -190,23 +207,19 @@ func (r *ServiceBodyResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	serviceBody, httpResp, err := r.client.Client.RootServerAPI.GetServiceBody(r.client.Context, id).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service body, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode == HTTPStatusNotFound {
+	if httpResp != nil && httpResp.StatusCode == HTTPStatusNotFound {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read service body", httpResp, err, data)
 		return
 	}
 
 	r.updateModelFromServiceBody(data, serviceBody)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *ServiceBodyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -223,10 +236,31 @@ func (r *ServiceBodyResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	// Convert assigned user IDs
-	var assignedUserIds []int32
-	for _, id := range data.AssignedUserIds {
-		assignedUserIds = append(assignedUserIds, safeInt64ToInt32(id.ValueInt64()))
+	// Assignments are only managed here when configured, otherwise the current
+	// server assignments are sent back unchanged
+	var configuredUserIds types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("assigned_user_ids"), &configuredUserIds)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Serialize with assignment resources updating the same service body
+	unlock := r.client.ServiceBodyLocks.Lock(data.Id.ValueString())
+	defer unlock()
+
+	assignedUserIds := []int32{}
+	if configuredUserIds.IsNull() {
+		current, httpResp, err := r.client.Client.RootServerAPI.GetServiceBody(r.client.Context, id).Execute()
+		if err != nil || httpResp.StatusCode != HTTPStatusOK {
+			addAPIError(&resp.Diagnostics, "read service body", httpResp, err, data)
+			return
+		}
+		assignedUserIds = append(assignedUserIds, current.AssignedUserIds...)
+	} else {
+		resp.Diagnostics.Append(data.AssignedUserIds.ElementsAs(ctx, &assignedUserIds, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	// Create NullableInt32 for ParentId
@@ -249,13 +283,8 @@ func (r *ServiceBodyResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	httpResp, err := r.client.Client.RootServerAPI.UpdateServiceBody(r.client.Context, id).ServiceBodyUpdate(updateRequest).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update service body, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusNoContent {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(&resp.Diagnostics, "update service body", httpResp, err, data)
 		return
 	}
 
@@ -267,13 +296,8 @@ func (r *ServiceBodyResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	updatedServiceBody, httpResp, err := r.client.Client.RootServerAPI.GetServiceBody(r.client.Context, serviceBodyId).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated service body, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d when reading updated service body", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read updated service body", httpResp, err, data)
 		return
 	}
 
@@ -281,6 +305,7 @@ func (r *ServiceBodyResource) Update(ctx context.Context, req resource.UpdateReq
 	r.updateModelFromServiceBody(data, updatedServiceBody)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *ServiceBodyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -302,19 +327,46 @@ func (r *ServiceBodyResource) Delete(ctx context.Context, req resource.DeleteReq
 		deleteReq = deleteReq.Force("true")
 	}
 	httpResp, err := deleteReq.Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete service body, got error: %s", err))
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(&resp.Diagnostics, "delete service body", httpResp, err, data)
 		return
 	}
+}
 
-	if httpResp.StatusCode != HTTPStatusNoContent {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
-		return
+func (r *ServiceBodyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored assigned_user_ids as a list
+		0: listToSetStateUpgrader("assigned_user_ids"),
 	}
 }
 
+// ImportState accepts the numeric service body ID or name:<service body name>,
+// or an import block identity
 func (r *ServiceBodyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID == "" {
+		importStateFromIdentity(ctx, r.client, req.Identity, resp)
+		return
+	}
+
+	name, ok := strings.CutPrefix(req.ID, "name:")
+	if !ok || name == "" {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	serviceBodies, ok := getServiceBodies(ctx, r.client, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var matches []int32
+	for _, serviceBody := range serviceBodies {
+		if serviceBody.Name == name {
+			matches = append(matches, serviceBody.Id)
+		}
+	}
+
+	setImportedID(ctx, req, resp, "Service Body", matches)
 }
 
 // Helper function to update model from API response
@@ -336,9 +388,5 @@ func (r *ServiceBodyResource) updateModelFromServiceBody(data *ServiceBodyResour
 	data.WorldId = nullableString(serviceBody.WorldId)
 
 	// Handle assigned user IDs
-	var assignedUserIds []types.Int64
-	for _, userId := range serviceBody.AssignedUserIds {
-		assignedUserIds = append(assignedUserIds, types.Int64Value(int64(userId)))
-	}
-	data.AssignedUserIds = assignedUserIds
+	data.AssignedUserIds = int64SetValue(serviceBody.AssignedUserIds)
 }
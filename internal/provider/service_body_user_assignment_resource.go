@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &ServiceBodyUserAssignmentResource{}
+var _ resource.ResourceWithImportState = &ServiceBodyUserAssignmentResource{}
+
+func NewServiceBodyUserAssignmentResource() resource.Resource {
+	return &ServiceBodyUserAssignmentResource{}
+}
+
+type ServiceBodyUserAssignmentResource struct {
+	client *BMTLClientData
+}
+
+type ServiceBodyUserAssignmentResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	ServiceBodyId types.Int64  `tfsdk:"service_body_id"`
+	UserId        types.Int64  `tfsdk:"user_id"`
+}
+
+func (r *ServiceBodyUserAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_body_user_assignment"
+}
+
+func (r *ServiceBodyUserAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assigns a single user to a service body without managing the service body's other assignments. " +
+			"Leave assigned_user_ids unset on the bmlt_service_body when using this resource.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Assignment identifier in the form service_body_id/user_id",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_body_id": schema.Int64Attribute{
+				MarkdownDescription: "Service body identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the user to assign",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ServiceBodyUserAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BMTLClientData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			clientTypeError(req.ProviderData),
+		)
+		return
+	}
+
+	if !requireAuthentication(client, &resp.Diagnostics) {
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ServiceBodyUserAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ServiceBodyUserAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setAssignment(data, true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(fmt.Sprintf("%d/%d", data.ServiceBodyId.ValueInt64(), data.UserId.ValueInt64()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceBodyUserAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ServiceBodyUserAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceBody, httpResp, err := r.client.Client.RootServerAPI.GetServiceBody(r.client.Context, data.ServiceBodyId.ValueInt64()).Execute()
+	if httpResp != nil && httpResp.StatusCode == HTTPStatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read service body", httpResp, err, data)
+		return
+	}
+
+	// The user was unassigned outside of Terraform
+	if !slices.Contains(serviceBody.AssignedUserIds, safeInt64ToInt32(data.UserId.ValueInt64())) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceBodyUserAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, so there is nothing to update
+	var data *ServiceBodyUserAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceBodyUserAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ServiceBodyUserAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setAssignment(data, false, &resp.Diagnostics)
+}
+
+func (r *ServiceBodyUserAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	serviceBodyPart, userPart, ok := strings.Cut(req.ID, "/")
+	serviceBodyId, serviceBodyErr := strconv.ParseInt(serviceBodyPart, 10, 64)
+	userId, userErr := strconv.ParseInt(userPart, 10, 64)
+	if !ok || serviceBodyErr != nil || userErr != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID in the form service_body_id/user_id (e.g., 42/7), got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_body_id"), serviceBodyId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userId)...)
+}
+
+// setAssignment adds or removes the user in the service body's assigned users,
+// leaving every other assignment and service body field unchanged
+func (r *ServiceBodyUserAssignmentResource) setAssignment(data *ServiceBodyUserAssignmentResourceModel, assigned bool, diags *diag.Diagnostics) {
+	serviceBodyId := data.ServiceBodyId.ValueInt64()
+	userId := safeInt64ToInt32(data.UserId.ValueInt64())
+
+	// Serialize with other assignments of the same service body so concurrent
+	// read-modify-write updates do not overwrite each other
+	unlock := r.client.ServiceBodyLocks.Lock(strconv.FormatInt(serviceBodyId, 10))
+	defer unlock()
+
+	serviceBody, httpResp, err := r.client.Client.RootServerAPI.GetServiceBody(r.client.Context, serviceBodyId).Execute()
+	if httpResp != nil && httpResp.StatusCode == HTTPStatusNotFound && !assigned {
+		// Removing the service body also removed the assignment
+		return
+	}
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(diags, "read service body", httpResp, err, data)
+		return
+	}
+
+	index := slices.Index(serviceBody.AssignedUserIds, userId)
+	if (index >= 0) == assigned {
+		return
+	}
+
+	assignedUserIds := append([]int32{}, serviceBody.AssignedUserIds...)
+	if assigned {
+		assignedUserIds = append(assignedUserIds, userId)
+	} else {
+		assignedUserIds = slices.Delete(assignedUserIds, index, index+1)
+	}
+
+	updateRequest := bmlt.ServiceBodyUpdate{
+		ParentId:        serviceBody.ParentId,
+		Name:            serviceBody.Name,
+		Description:     serviceBody.Description,
+		Type:            serviceBody.Type,
+		AdminUserId:     serviceBody.AdminUserId,
+		AssignedUserIds: assignedUserIds,
+		Url:             bmlt.PtrString(serviceBody.Url),
+		Helpline:        bmlt.PtrString(serviceBody.Helpline),
+		Email:           bmlt.PtrString(serviceBody.Email),
+		WorldId:         bmlt.PtrString(serviceBody.WorldId),
+	}
+
+	action := "assign user to service body"
+	if !assigned {
+		action = "unassign user from service body"
+	}
+
+	httpResp, err = r.client.Client.RootServerAPI.UpdateServiceBody(r.client.Context, serviceBodyId).ServiceBodyUpdate(updateRequest).Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(diags, action, httpResp, err, data)
+	}
+}
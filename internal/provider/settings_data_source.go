@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -168,6 +167,10 @@ func (d *SettingsDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
+	if !requireAuthentication(client, &resp.Diagnostics) {
+		return
+	}
+
 	d.client = client
 }
 
@@ -183,13 +186,8 @@ func (d *SettingsDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	// Get settings from API
 	settings, httpResp, err := d.client.Client.RootServerAPI.GetSettings(d.client.Context).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read settings, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read settings", httpResp, err, nil)
 		return
 	}
 
@@ -5,15 +5,22 @@ import (
 	"fmt"
 
 	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// settingsId is the ID of the only settings object of a root server
+const settingsId = "settings"
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SettingsResource{}
+var _ resource.ResourceWithIdentity = &SettingsResource{}
+var _ resource.ResourceWithImportState = &SettingsResource{}
 
 func NewSettingsResource() resource.Resource {
 	return &SettingsResource{}
@@ -54,6 +61,15 @@ func (r *SettingsResource) Metadata(ctx context.Context, req resource.MetadataRe
 	resp.TypeName = req.ProviderTypeName + "_settings"
 }
 
+func (r *SettingsResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	// Every root server has exactly one settings object
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"host": hostIdentityAttribute,
+		},
+	}
+}
+
 func (r *SettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Settings resource. This is a singleton resource that manages the BMLT server settings. Since settings always exist on the server, there is no create or delete operation - only read and update.",
@@ -175,6 +191,10 @@ func (r *SettingsResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
+	if !requireAuthentication(client, &resp.Diagnostics) {
+		return
+	}
+
 	r.client = client
 }
 
@@ -195,26 +215,15 @@ func (r *SettingsResource) Create(ctx context.Context, req resource.CreateReques
 	// Update settings
 	httpResp, err := r.client.Client.RootServerAPI.UpdateSettings(r.client.Context).
 		SettingsUpdate(*updateRequest).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update settings, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusNoContent {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(&resp.Diagnostics, "update settings", httpResp, err, data)
 		return
 	}
 
 	// Re-read the settings to ensure state is consistent with server
 	updatedSettings, httpResp, err := r.client.Client.RootServerAPI.GetSettings(r.client.Context).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated settings, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error",
-			fmt.Sprintf("API returned status %d when reading updated settings", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read updated settings", httpResp, err, data)
 		return
 	}
 
@@ -223,6 +232,7 @@ func (r *SettingsResource) Create(ctx context.Context, req resource.CreateReques
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &ServerIdentityModel{Host: r.client.host()})...)
 }
 
 func (r *SettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -237,13 +247,8 @@ func (r *SettingsResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	// Get settings from API
 	settings, httpResp, err := r.client.Client.RootServerAPI.GetSettings(r.client.Context).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read settings, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read settings", httpResp, err, data)
 		return
 	}
 
@@ -252,6 +257,7 @@ func (r *SettingsResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &ServerIdentityModel{Host: r.client.host()})...)
 }
 
 func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -270,26 +276,15 @@ func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateReques
 	// Update settings
 	httpResp, err := r.client.Client.RootServerAPI.UpdateSettings(r.client.Context).
 		SettingsUpdate(*updateRequest).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update settings, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusNoContent {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(&resp.Diagnostics, "update settings", httpResp, err, data)
 		return
 	}
 
 	// Re-read the settings to ensure state is consistent with server
 	updatedSettings, httpResp, err := r.client.Client.RootServerAPI.GetSettings(r.client.Context).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated settings, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error",
-			fmt.Sprintf("API returned status %d when reading updated settings", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read updated settings", httpResp, err, data)
 		return
 	}
 
@@ -298,6 +293,7 @@ func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateReques
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &ServerIdentityModel{Host: r.client.host()})...)
 }
 
 func (r *SettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -306,6 +302,24 @@ func (r *SettingsResource) Delete(ctx context.Context, req resource.DeleteReques
 	// No API call is needed here
 }
 
+// ImportState imports the settings of the root server, by the settings ID or
+// by an import block identity
+func (r *SettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		if !checkIdentityHost(ctx, r.client, req.Identity, &resp.Diagnostics) {
+			return
+		}
+	} else if req.ID != settingsId {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected %q, got: %q", settingsId, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), settingsId)...)
+}
+
 // buildUpdateRequest creates a SettingsUpdate object from the model
 func (r *SettingsResource) buildUpdateRequest(data *SettingsResourceModel) *bmlt.SettingsUpdate {
 	updateRequest := bmlt.NewSettingsUpdate()
@@ -408,7 +422,7 @@ func (r *SettingsResource) buildUpdateRequest(data *SettingsResourceModel) *bmlt
 // mapSettingsToModel maps a SettingsObject to the resource model
 func (r *SettingsResource) mapSettingsToModel(settings *bmlt.SettingsObject, data *SettingsResourceModel) {
 	// Set a constant ID for this singleton resource
-	data.Id = types.StringValue("settings")
+	data.Id = types.StringValue(settingsId)
 
 	if settings.GoogleApiKey != nil {
 		data.GoogleApiKey = types.StringValue(*settings.GoogleApiKey)
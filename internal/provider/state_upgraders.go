@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// listToSetStateUpgrader upgrades state from a schema version that stored the
+// given attributes as lists instead of sets. Lists and sets share the same
+// JSON encoding, so only duplicate elements need to be removed.
+func listToSetStateUpgrader(attributes ...string) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil || req.RawState.JSON == nil {
+				resp.Diagnostics.AddError("Unable to Upgrade State", "The prior state is not in JSON format.")
+				return
+			}
+
+			decoder := json.NewDecoder(bytes.NewReader(req.RawState.JSON))
+			decoder.UseNumber()
+
+			var state map[string]interface{}
+			if err := decoder.Decode(&state); err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade State", fmt.Sprintf("Unable to decode the prior state: %s", err))
+				return
+			}
+
+			for _, attribute := range attributes {
+				values, ok := state[attribute].([]interface{})
+				if !ok {
+					continue
+				}
+
+				seen := make(map[string]bool, len(values))
+				unique := make([]interface{}, 0, len(values))
+				for _, value := range values {
+					key := fmt.Sprint(value)
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+					unique = append(unique, value)
+				}
+				state[attribute] = unique
+			}
+
+			upgraded, err := json.Marshal(state)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade State", fmt.Sprintf("Unable to encode the upgraded state: %s", err))
+				return
+			}
+
+			resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+		},
+	}
+}
@@ -0,0 +1,494 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// tlsOptions describes the TLS and proxy settings of the provider HTTP client
+type tlsOptions struct {
+	// CACertPEM and CACertFile add certificate authorities to the system pool
+	CACertPEM  string
+	CACertFile string
+	// ClientCert and ClientKey are PEM encoded and must be set together
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+	// ProxyURL overrides the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables
+	ProxyURL string
+}
+
+// newBaseTransport returns a copy of http.DefaultTransport configured with the
+// given TLS and proxy settings
+func newBaseTransport(opts tlsOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.InsecureSkipVerify, //nolint:gosec // explicitly requested by the provider configuration
+	}
+
+	if opts.CACertPEM != "" || opts.CACertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if opts.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(opts.CACertPEM)) {
+			return nil, errors.New("ca_cert_pem does not contain any valid PEM encoded certificates")
+		}
+
+		if opts.CACertFile != "" {
+			pem, err := os.ReadFile(opts.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read ca_cert_file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("ca_cert_file %s does not contain any valid PEM encoded certificates", opts.CACertFile)
+			}
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if opts.ClientCert != "" || opts.ClientKey != "" {
+		if opts.ClientCert == "" || opts.ClientKey == "" {
+			return nil, errors.New("client_cert and client_key must be set together")
+		}
+		cert, err := tls.X509KeyPair([]byte(opts.ClientCert), []byte(opts.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("proxy_url must be an absolute URL such as http://proxy.example.com:3128, got: %q", opts.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
+
+// Default retry behaviour for transient API failures
+const (
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// retryTransport is an http.RoundTripper that retries transient BMLT API
+// failures with exponential backoff. Idempotent requests are retried on
+// network errors and 5xx responses; any request is retried on 429 since the
+// server rejected it without processing it.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+}
+
+// newRetryTransport wraps next with retry behaviour
+func newRetryTransport(next http.RoundTripper, maxRetries int, waitMin, waitMax time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		waitMin:    waitMin,
+		waitMax:    waitMax,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		// Retries send a copy with a fresh body, RoundTrippers must not modify the request
+		attemptReq := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		retryFields := map[string]interface{}{
+			"http_method":   req.Method,
+			"http_url":      req.URL.Redacted(),
+			"retry_attempt": attempt + 1,
+			"retry_wait":    wait.String(),
+		}
+		if err != nil {
+			retryFields["error"] = err.Error()
+		} else {
+			retryFields["http_status"] = resp.StatusCode
+		}
+		tflog.Debug(req.Context(), "Retrying BMLT API request", retryFields)
+
+		// Drain and close the body so the connection can be reused
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether a request should be retried given its outcome
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	// Bodies without GetBody cannot be replayed
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if !isIdempotentMethod(req.Method) {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented
+}
+
+// backoff returns how long to wait before the next attempt, honoring the
+// Retry-After header when the server sends one. Waits never exceed waitMax.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, t.waitMax)
+		}
+	}
+
+	wait := t.waitMin << attempt
+	if wait <= 0 || wait > t.waitMax {
+		wait = t.waitMax
+	}
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// isIdempotentMethod reports whether an HTTP method is idempotent
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestLimiter throttles BMLT API requests to a maximum rate and a maximum
+// number of requests in flight. A single limiter is shared by every resource
+// and data source through the provider HTTP client. Zero values disable the limits.
+type requestLimiter struct {
+	rate      *rate.Limiter
+	semaphore chan struct{}
+}
+
+// newRequestLimiter creates a limiter allowing requestsPerSecond requests per
+// second and maxConcurrent requests in flight
+func newRequestLimiter(requestsPerSecond float64, maxConcurrent int) *requestLimiter {
+	l := &requestLimiter{}
+	if requestsPerSecond > 0 {
+		l.rate = rate.NewLimiter(rate.Limit(requestsPerSecond), 1)
+	}
+	if maxConcurrent > 0 {
+		l.semaphore = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+// acquire blocks until a request may be sent and returns a function that
+// releases the request's concurrency slot
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.semaphore != nil {
+		select {
+		case l.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if l.semaphore != nil {
+			<-l.semaphore
+		}
+	}
+
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// limitTransport is an http.RoundTripper that applies a requestLimiter to
+// every request. The concurrency slot is held until the response body is closed.
+type limitTransport struct {
+	next    http.RoundTripper
+	limiter *requestLimiter
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseOnCloseBody calls release exactly once when the body is closed
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// redactedValue replaces sensitive values in logged requests and responses
+const redactedValue = "***"
+
+// sensitiveFields are JSON keys and form fields whose values are never logged
+var sensitiveFields = map[string]bool{
+	"password":       true,
+	"access_token":   true,
+	"refresh_token":  true,
+	"client_secret":  true,
+	"googleapikey":   true,
+	"google_api_key": true,
+}
+
+// loggingTransport is an http.RoundTripper that logs BMLT API requests through
+// tflog. Method, URL, status and latency are logged at DEBUG, and when
+// traceBodies is set, redacted request and response bodies are logged at TRACE.
+type loggingTransport struct {
+	next        http.RoundTripper
+	traceBodies bool
+}
+
+// newLoggingTransport wraps next with request logging. Bodies are only
+// captured when Terraform logging is set to TRACE, since reading them costs
+// memory for large meeting lists.
+func newLoggingTransport(next http.RoundTripper) *loggingTransport {
+	traceBodies := false
+	for _, envVar := range []string{"TF_LOG", "TF_LOG_PROVIDER"} {
+		switch strings.ToUpper(os.Getenv(envVar)) {
+		case "TRACE", "JSON":
+			traceBodies = true
+		}
+	}
+	return &loggingTransport{next: next, traceBodies: traceBodies}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    req.URL.Redacted(),
+	}
+
+	if t.traceBodies {
+		traceFields := map[string]interface{}{
+			"http_request_headers": redactHeaders(req.Header),
+		}
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				data, _ := io.ReadAll(body)
+				_ = body.Close()
+				traceFields["http_request_body"] = redactBody(data, req.Header.Get("Content-Type"))
+			}
+		}
+		tflog.Trace(ctx, "Sending BMLT API request", mergeFields(fields, traceFields))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "BMLT API request failed", fields)
+		return resp, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	tflog.Debug(ctx, "BMLT API request completed", fields)
+
+	if t.traceBodies && resp.Body != nil {
+		data, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if readErr != nil {
+			return resp, readErr
+		}
+		tflog.Trace(ctx, "Received BMLT API response", mergeFields(fields, map[string]interface{}{
+			"http_response_headers": redactHeaders(resp.Header),
+			"http_response_body":    redactBody(data, resp.Header.Get("Content-Type")),
+		}))
+	}
+
+	return resp, nil
+}
+
+// mergeFields returns a new map containing the fields of a and b
+func mergeFields(a, b map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(a)+len(b))
+	for k, v := range a {
+		merged[k] = v
+	}
+	for k, v := range b {
+		merged[k] = v
+	}
+	return merged
+}
+
+// redactHeaders returns the headers as a map with credentials masked
+func redactHeaders(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+	for name, values := range headers {
+		switch http.CanonicalHeaderKey(name) {
+		case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+			result[name] = redactedValue
+		default:
+			result[name] = strings.Join(values, ", ")
+		}
+	}
+	return result
+}
+
+// redactBody returns a loggable representation of a JSON or form encoded body
+// with sensitive fields masked. Other bodies are summarized by size only.
+func redactBody(data []byte, contentType string) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(data))
+		if err == nil {
+			for key := range values {
+				if sensitiveFields[strings.ToLower(key)] {
+					values.Set(key, redactedValue)
+				}
+			}
+			return values.Encode()
+		}
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Sprintf("<%d bytes of non-JSON content omitted>", len(data))
+	}
+
+	redacted, err := json.Marshal(redactJSON(decoded))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes omitted>", len(data))
+	}
+	return string(redacted)
+}
+
+// redactJSON masks sensitive keys in a decoded JSON value
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if sensitiveFields[strings.ToLower(key)] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactJSON(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -95,6 +95,10 @@ func (d *UserDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
+	if !requireAuthentication(client, &resp.Diagnostics) {
+		return
+	}
+
 	d.client = client
 }
 
@@ -130,18 +134,13 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	if hasUserId {
 		userId := data.UserId.ValueInt64()
 		user, httpResp, err := d.client.Client.RootServerAPI.GetUser(d.client.Context, userId).Execute()
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
-			return
-		}
-
-		if httpResp.StatusCode == HTTPStatusNotFound {
+		if httpResp != nil && httpResp.StatusCode == HTTPStatusNotFound {
 			resp.Diagnostics.AddError("User Not Found", fmt.Sprintf("User with ID %d not found", userId))
 			return
 		}
 
-		if httpResp.StatusCode != HTTPStatusOK {
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+		if err != nil || httpResp.StatusCode != HTTPStatusOK {
+			addAPIError(&resp.Diagnostics, "read user", httpResp, err, nil)
 			return
 		}
 
@@ -159,13 +158,8 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		// If username is provided, fetch all users and filter
 		targetUsername := data.Username.ValueString()
 		users, httpResp, err := d.client.Client.RootServerAPI.GetUsers(d.client.Context).Execute()
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read users, got error: %s", err))
-			return
-		}
-
-		if httpResp.StatusCode != HTTPStatusOK {
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+		if err != nil || httpResp.StatusCode != HTTPStatusOK {
+			addAPIError(&resp.Diagnostics, "read users", httpResp, err, nil)
 			return
 		}
 
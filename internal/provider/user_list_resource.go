@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &UserResource{}
+var _ list.ListResourceWithConfigure = &UserResource{}
+
+func NewUserListResource() list.ListResource {
+	return &UserResource{}
+}
+
+type UserListResourceModel struct {
+	OwnerId types.Int64 `tfsdk:"owner_id"`
+}
+
+func (r *UserResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists existing users so they can be imported with terraform query. Passwords are never returned by the server.",
+
+		Attributes: map[string]listschema.Attribute{
+			"owner_id": listschema.Int64Attribute{
+				MarkdownDescription: "Only list users owned by this user",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *UserResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config UserListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	users, httpResp, err := r.client.Client.RootServerAPI.GetUsers(r.client.Context).Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&diags, "read users", httpResp, err, nil)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if !config.OwnerId.IsNull() {
+		var owned []bmlt.User
+		for _, user := range users {
+			if int64(user.OwnerId) == config.OwnerId.ValueInt64() {
+				owned = append(owned, user)
+			}
+		}
+		users = owned
+	}
+
+	stream.Results = streamListResults(req, users, func(user bmlt.User) list.ListResult {
+		result := req.NewListResult(ctx)
+		result.DisplayName = user.Username
+
+		data := &UserResourceModel{
+			Id:      types.StringValue(strconv.Itoa(int(user.Id))),
+			Keepers: types.MapNull(types.StringType),
+		}
+		r.updateModelFromUser(data, &user)
+
+		result.Diagnostics.Append(result.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
+		if req.IncludeResource {
+			result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+		}
+		return result
+	})
+}
@@ -2,20 +2,36 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithIdentity = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
+
+// Generated password defaults
+const (
+	defaultPasswordLength  = 24
+	defaultPasswordCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789!#$%&*()-_=+[]{}<>:?"
+)
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
@@ -26,21 +42,34 @@ type UserResource struct {
 }
 
 type UserResourceModel struct {
-	Id          types.String `tfsdk:"id"`
-	Username    types.String `tfsdk:"username"`
-	Password    types.String `tfsdk:"password"`
-	Type        types.String `tfsdk:"type"`
-	DisplayName types.String `tfsdk:"display_name"`
-	Description types.String `tfsdk:"description"`
-	Email       types.String `tfsdk:"email"`
-	OwnerId     types.Int64  `tfsdk:"owner_id"`
-	LastLoginAt types.String `tfsdk:"last_login_at"`
+	Id                types.String `tfsdk:"id"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWo        types.String `tfsdk:"password_wo"`
+	PasswordWoVersion types.Int64  `tfsdk:"password_wo_version"`
+	GeneratePassword  types.Bool   `tfsdk:"generate_password"`
+	PasswordLength    types.Int64  `tfsdk:"password_length"`
+	PasswordCharset   types.String `tfsdk:"password_charset"`
+	RotateAfter       types.String `tfsdk:"rotate_after"`
+	Keepers           types.Map    `tfsdk:"keepers"`
+	GeneratedPassword types.String `tfsdk:"generated_password"`
+	PasswordCreatedAt types.String `tfsdk:"password_created_at"`
+	Type              types.String `tfsdk:"type"`
+	DisplayName       types.String `tfsdk:"display_name"`
+	Description       types.String `tfsdk:"description"`
+	Email             types.String `tfsdk:"email"`
+	OwnerId           types.Int64  `tfsdk:"owner_id"`
+	LastLoginAt       types.String `tfsdk:"last_login_at"`
 }
 
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_user"
 }
 
+func (r *UserResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = objectIdentitySchema("User identifier")
+}
+
 func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "User resource",
@@ -58,9 +87,83 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Required:            true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "User password",
+				MarkdownDescription: "User password. The password is stored in state, use password_wo to avoid this.",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password_wo"), path.MatchRoot("generate_password")),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only user password, never stored in the plan or state. It is sent when the user is created " +
+					"and whenever password_wo_version changes. Requires Terraform 1.11 or later.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password"), path.MatchRoot("generate_password")),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of password_wo. Change this value to update the user's password to the current password_wo.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
+			"generate_password": schema.BoolAttribute{
+				MarkdownDescription: "Generate a random password for the user instead of setting password or password_wo. " +
+					"The generated password is available in generated_password.",
+				Optional: true,
+			},
+			"password_length": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Length of the generated password. Changing it generates a new password. Defaults to %d.", defaultPasswordLength),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(12, 128),
+					int64validator.AlsoRequires(path.MatchRoot("generate_password")),
+				},
+			},
+			"password_charset": schema.StringAttribute{
+				MarkdownDescription: "Characters the generated password is drawn from. Changing it generates a new password. " +
+					"Defaults to upper and lower case letters, digits and punctuation.",
+				Optional: true,
+				Validators: []validator.String{
+					runeCountAtLeast(10),
+					stringvalidator.AlsoRequires(path.MatchRoot("generate_password")),
+				},
+			},
+			"rotate_after": schema.StringAttribute{
+				MarkdownDescription: "Generate a new password when the current one is older than this duration (e.g., 2160h for 90 days). " +
+					"The first refresh after the duration has passed marks the password for rotation, and the following apply generates a new one.",
+				Optional: true,
+				Validators: []validator.String{
+					validDuration(),
+					stringvalidator.AlsoRequires(path.MatchRoot("generate_password")),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that generate a new password whenever they change.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot("generate_password")),
+				},
+			},
+			"generated_password": schema.StringAttribute{
+				MarkdownDescription: "The generated password, when generate_password is true.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"password_created_at": schema.StringAttribute{
+				MarkdownDescription: "Time the generated password was created, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "User type",
@@ -104,6 +207,10 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
+	if !requireAuthentication(client, &resp.Diagnostics) {
+		return
+	}
+
 	r.client = client
 }
 
@@ -115,10 +222,32 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	// Write-only attributes are only available in the configuration
+	var passwordWo types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password := data.Password.ValueString()
+	if !passwordWo.IsNull() {
+		password = passwordWo.ValueString()
+	}
+	if data.GeneratePassword.ValueBool() {
+		var err error
+		if password, err = r.generatePassword(data); err != nil {
+			resp.Diagnostics.AddError("Password Generation Error", fmt.Sprintf("Unable to generate password: %s", err))
+			return
+		}
+	} else {
+		data.GeneratedPassword = types.StringNull()
+		data.PasswordCreatedAt = types.StringNull()
+	}
+
 	// Convert model to API request
 	createRequest := bmlt.UserCreate{
 		Username:    data.Username.ValueString(),
-		Password:    data.Password.ValueString(),
+		Password:    password,
 		Type:        data.Type.ValueString(),
 		DisplayName: data.DisplayName.ValueString(),
 		Description: data.Description.ValueStringPointer(),
@@ -133,13 +262,8 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Create user
 	user, httpResp, err := r.client.Client.RootServerAPI.CreateUser(r.client.Context).UserCreate(createRequest).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusCreated {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusCreated {
+		addAPIError(&resp.Diagnostics, "create user", httpResp, err, data)
 		return
 	}
 
@@ -148,6 +272,7 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	r.updateModelFromUser(data, user)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -165,23 +290,27 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	user, httpResp, err := r.client.Client.RootServerAPI.GetUser(r.client.Context, id).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode == HTTPStatusNotFound {
+	if httpResp != nil && httpResp.StatusCode == HTTPStatusNotFound {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read user", httpResp, err, data)
 		return
 	}
 
 	r.updateModelFromUser(data, user)
+
+	// Like time_rotating, a password that is due for rotation is marked during
+	// refresh by clearing its creation time, so plans only react to state they
+	// have already seen and a saved plan does not change when it is applied
+	if passwordRotationDue(data) {
+		data.PasswordCreatedAt = types.StringNull()
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -208,19 +337,45 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		OwnerId:     nil, // Handle OwnerId separately
 	}
 
+	// The write-only password is only sent when its version changes
+	var priorPasswordWoVersion types.Int64
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("password_wo_version"), &priorPasswordWoVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.PasswordWoVersion.Equal(priorPasswordWoVersion) {
+		var passwordWo types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWo)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !passwordWo.IsNull() {
+			updateRequest.Password = passwordWo.ValueStringPointer()
+		}
+	}
+
+	// A new password is generated whenever the plan rotates it
+	if data.GeneratePassword.ValueBool() && data.GeneratedPassword.IsUnknown() {
+		password, err := r.generatePassword(data)
+		if err != nil {
+			resp.Diagnostics.AddError("Password Generation Error", fmt.Sprintf("Unable to generate password: %s", err))
+			return
+		}
+		updateRequest.Password = &password
+	} else if !data.GeneratePassword.ValueBool() {
+		data.GeneratedPassword = types.StringNull()
+		data.PasswordCreatedAt = types.StringNull()
+	}
+
 	// Handle optional OwnerId
 	if !data.OwnerId.IsNull() {
 		updateRequest.OwnerId = bmlt.PtrInt32(safeInt64ToInt32(data.OwnerId.ValueInt64()))
 	}
 
 	httpResp, err := r.client.Client.RootServerAPI.UpdateUser(r.client.Context, id).UserUpdate(updateRequest).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusNoContent {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(&resp.Diagnostics, "update user", httpResp, err, data)
 		return
 	}
 
@@ -232,13 +387,8 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	updatedUser, httpResp, err := r.client.Client.RootServerAPI.GetUser(r.client.Context, userId).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated user, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d when reading updated user", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read updated user", httpResp, err, data)
 		return
 	}
 
@@ -246,6 +396,7 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	r.updateModelFromUser(data, updatedUser)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, r.client.objectIdentity(data.Id))...)
 }
 
 func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -263,19 +414,106 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	httpResp, err := r.client.Client.RootServerAPI.DeleteUser(r.client.Context, id).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user, got error: %s", err))
+	if err != nil || httpResp.StatusCode != HTTPStatusNoContent {
+		addAPIError(&resp.Diagnostics, "delete user", httpResp, err, data)
 		return
 	}
+}
 
-	if httpResp.StatusCode != HTTPStatusNoContent {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
 		return
 	}
+
+	var plan *UserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Whether a password is generated is only known during apply
+	if plan.GeneratePassword.IsUnknown() {
+		plan.GeneratedPassword = types.StringUnknown()
+		plan.PasswordCreatedAt = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	if !plan.GeneratePassword.ValueBool() {
+		plan.GeneratedPassword = types.StringNull()
+		plan.PasswordCreatedAt = types.StringNull()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	// New users always get a new password
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state *UserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read clears password_created_at once rotate_after has passed
+	rotate := state.GeneratedPassword.IsNull() ||
+		state.PasswordCreatedAt.IsNull() ||
+		!plan.Keepers.Equal(state.Keepers) ||
+		!plan.PasswordLength.Equal(state.PasswordLength) ||
+		!plan.PasswordCharset.Equal(state.PasswordCharset)
+
+	if rotate {
+		plan.GeneratedPassword = types.StringUnknown()
+		plan.PasswordCreatedAt = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+}
+
+// passwordRotationDue reports whether the generated password of a user is older
+// than rotate_after
+func passwordRotationDue(data *UserResourceModel) bool {
+	if !data.GeneratePassword.ValueBool() || data.PasswordCreatedAt.IsNull() || data.RotateAfter.IsNull() {
+		return false
+	}
+
+	rotateAfter, err := time.ParseDuration(data.RotateAfter.ValueString())
+	if err != nil {
+		return false
+	}
+	createdAt, err := time.Parse(time.RFC3339, data.PasswordCreatedAt.ValueString())
+	return err != nil || time.Since(createdAt) >= rotateAfter
 }
 
+// ImportState accepts the numeric user ID or username:<username>, or an import block identity
 func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID == "" {
+		importStateFromIdentity(ctx, r.client, req.Identity, resp)
+		return
+	}
+
+	username, ok := strings.CutPrefix(req.ID, "username:")
+	if !ok || username == "" {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	users, httpResp, err := r.client.Client.RootServerAPI.GetUsers(r.client.Context).Execute()
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read users", httpResp, err, nil)
+		return
+	}
+
+	var matches []int32
+	for _, user := range users {
+		if user.Username == username {
+			matches = append(matches, user.Id)
+		}
+	}
+
+	setImportedID(ctx, req, resp, "User", matches)
 }
 
 // Helper function to update model from API response
@@ -289,3 +527,29 @@ func (r *UserResource) updateModelFromUser(data *UserResourceModel, user *bmlt.U
 	data.LastLoginAt = nullableTime(user.LastLoginAt)
 	// Note: Password is not returned from API for security reasons
 }
+
+// generatePassword creates a random password using the configured length and
+// charset, recording it and its creation time in the model
+func (r *UserResource) generatePassword(data *UserResourceModel) (string, error) {
+	length := int64(defaultPasswordLength)
+	if !data.PasswordLength.IsNull() {
+		length = data.PasswordLength.ValueInt64()
+	}
+	charset := []rune(defaultPasswordCharset)
+	if !data.PasswordCharset.IsNull() {
+		charset = []rune(data.PasswordCharset.ValueString())
+	}
+
+	password := make([]rune, length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = charset[n.Int64()]
+	}
+
+	data.GeneratedPassword = types.StringValue(string(password))
+	data.PasswordCreatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	return string(password), nil
+}
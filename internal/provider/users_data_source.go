@@ -101,6 +101,10 @@ func (d *UsersDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
+	if !requireAuthentication(client, &resp.Diagnostics) {
+		return
+	}
+
 	d.client = client
 }
 
@@ -114,13 +118,8 @@ func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	// Get users from the API
 	users, httpResp, err := d.client.Client.RootServerAPI.GetUsers(d.client.Context).Execute()
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read users, got error: %s", err))
-		return
-	}
-
-	if httpResp.StatusCode != HTTPStatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("API returned status %d", httpResp.StatusCode))
+	if err != nil || httpResp.StatusCode != HTTPStatusOK {
+		addAPIError(&resp.Diagnostics, "read users", httpResp, err, nil)
 		return
 	}
 
@@ -1,10 +1,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"sync"
 
 	"github.com/bmlt-enabled/bmlt-server-go-client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -50,3 +54,81 @@ func nullableTime(t bmlt.NullableTime) types.String {
 	}
 	return types.StringValue(t.Get().String())
 }
+
+// Helper function to convert a custom fields model map to the API representation
+// Returns nil when the map is null so the server keeps its existing values
+func customFieldsFromModel(ctx context.Context, fields types.Map, diags *diag.Diagnostics) *map[string]string {
+	if fields.IsNull() || fields.IsUnknown() {
+		return nil
+	}
+	var result map[string]string
+	diags.Append(fields.ElementsAs(ctx, &result, false)...)
+	if result == nil {
+		result = map[string]string{}
+	}
+	return &result
+}
+
+// Helper function to convert custom fields for an update request
+// Fields configured before but removed since are sent empty so the server clears them
+func customFieldsForUpdate(ctx context.Context, fields, prior types.Map, diags *diag.Diagnostics) *map[string]string {
+	result := customFieldsFromModel(ctx, fields, diags)
+	priorFields := customFieldsFromModel(ctx, prior, diags)
+	if priorFields == nil {
+		return result
+	}
+	for key := range *priorFields {
+		if result == nil {
+			result = &map[string]string{}
+		}
+		if _, ok := (*result)[key]; !ok {
+			(*result)[key] = ""
+		}
+	}
+	return result
+}
+
+// Helper function to convert API custom fields to a model map
+// Returns nil if the server did not return any custom fields
+func customFieldsToModel(fields *map[string]string) map[string]types.String {
+	if fields == nil {
+		return nil
+	}
+	result := make(map[string]types.String, len(*fields))
+	for key, value := range *fields {
+		result[key] = types.StringValue(value)
+	}
+	return result
+}
+
+// Helper function to convert API identifiers to a set of numbers
+func int64SetValue(ids []int32) types.Set {
+	elements := make([]attr.Value, 0, len(ids))
+	for _, id := range ids {
+		elements = append(elements, types.Int64Value(int64(id)))
+	}
+	return types.SetValueMust(types.Int64Type, elements)
+}
+
+// keyedMutex serializes read-modify-write updates of the same API object
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// Lock locks the mutex for key and returns a function that unlocks it
+func (m *keyedMutex) Lock(key string) func() {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*sync.Mutex)
+	}
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	// Embed the IANA time zone database so time zone validation does not
+	// depend on the zoneinfo files installed on the machine running Terraform.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// timeOfDayRegex matches HH:MM or HH:MM:SS times between 00:00 and 23:59:59
+var timeOfDayRegex = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9](:[0-5][0-9])?$`)
+
+// Ensure timeZoneValidator satisfies the validator interface.
+var _ validator.String = timeZoneValidator{}
+
+// timeZoneValidator validates that a string is an IANA time zone name known
+// to Go's time zone database (e.g., America/New_York)
+type timeZoneValidator struct{}
+
+// validTimeZone returns a validator which ensures the configured value is an
+// IANA time zone name.
+func validTimeZone() validator.String {
+	return timeZoneValidator{}
+}
+
+func (v timeZoneValidator) Description(ctx context.Context) string {
+	return "value must be an IANA time zone name (e.g., America/New_York)"
+}
+
+func (v timeZoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timeZoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	// time.LoadLocation also accepts "" and "Local", neither of which is
+	// meaningful to the BMLT server
+	if value == "" || value == "Local" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time Zone",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+		return
+	}
+
+	if _, err := time.LoadLocation(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time Zone",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// Ensure durationValidator satisfies the validator interface.
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that a string is a non-negative Go duration (e.g., 30s, 1m30s)
+type durationValidator struct{}
+
+// validDuration returns a validator which ensures the configured value is a
+// duration string.
+func validDuration() validator.String {
+	return durationValidator{}
+}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a non-negative duration such as 500ms, 30s or 1m30s"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if d, err := time.ParseDuration(value); err != nil || d < 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// Ensure runeCountValidator satisfies the validator interface.
+var _ validator.String = runeCountValidator{}
+
+// runeCountValidator validates that a string has at least a number of characters,
+// counting runes rather than bytes
+type runeCountValidator struct {
+	min int
+}
+
+// runeCountAtLeast returns a validator which ensures the configured value has
+// at least min characters.
+func runeCountAtLeast(min int) validator.String {
+	return runeCountValidator{min: min}
+}
+
+func (v runeCountValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must have at least %d characters", v.min)
+}
+
+func (v runeCountValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v runeCountValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if count := utf8.RuneCountInString(value); count < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value Length",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), count),
+		)
+	}
+}
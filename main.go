@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
@@ -30,6 +32,11 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		export(os.Args[2:])
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -46,3 +53,30 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// export writes Terraform configuration and import blocks for the objects of an
+// existing root server, see "terraform-provider-bmlt export -help"
+func export(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	host := flags.String("host", "", "URL of the BMLT root server, defaults to the BMLT_HOST environment variable")
+	username := flags.String("username", "", "username to sign in with, defaults to the BMLT_USERNAME environment variable")
+	out := flags.String("out", ".", "directory to write the Terraform configuration to")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: terraform-provider-bmlt export --host URL --out DIR\n\n"+
+			"Writes Terraform configuration and import blocks for the settings, users, formats,\n"+
+			"service bodies and meetings of a root server. The password or other credentials are\n"+
+			"read from the same environment variables as the provider.\n\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	err := provider.Export(context.Background(), provider.ExportOptions{
+		Host:     *host,
+		Username: *username,
+		OutDir:   *out,
+		Version:  version,
+	})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}